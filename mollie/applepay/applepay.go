@@ -0,0 +1,225 @@
+// Package applepay implements the server side of Apple Pay on the web.
+//
+// When the payment sheet opens, the browser receives a validation URL
+// from Apple that has to be forwarded to Mollie to start a merchant
+// session. Handler is the endpoint receiving it:
+//
+//	http.Handle("/apple-pay/session", applepay.NewHandler(client, &applepay.Options{
+//		Domain: "shop.example.org",
+//	}))
+//
+// Once the shopper authorizes the payment, the token returned by Apple
+// is used to create the payment:
+//
+//	p, err := applepay.Payment(ctx, client, token, mollie.Payment{
+//		Amount:      &mollie.Amount{Currency: "EUR", Value: "24.95"},
+//		Description: "Order 12345",
+//		RedirectURL: "https://shop.example.org/orders/12345",
+//	}, nil)
+//
+// The domains showing the payment sheet have to be registered for Apple
+// Pay in the Mollie Dashboard. The public v2 API has no endpoints to
+// register or list them, so the package offers no domain management.
+//
+// See: https://docs.mollie.com/reference/v2/wallets-api/request-apple-pay-payment-session
+package applepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Hosts are the hosts Apple sends validation URLs for, as listed in the
+// Apple Pay setup requirements.
+var Hosts = []string{
+	"apple-pay-gateway.apple.com",
+	"apple-pay-gateway-nc-pod1.apple.com",
+	"apple-pay-gateway-nc-pod2.apple.com",
+	"apple-pay-gateway-nc-pod3.apple.com",
+	"apple-pay-gateway-nc-pod4.apple.com",
+	"apple-pay-gateway-nc-pod5.apple.com",
+	"apple-pay-gateway-pr-pod1.apple.com",
+	"apple-pay-gateway-pr-pod2.apple.com",
+	"apple-pay-gateway-pr-pod3.apple.com",
+	"apple-pay-gateway-pr-pod4.apple.com",
+	"apple-pay-gateway-pr-pod5.apple.com",
+	"cn-apple-pay-gateway.apple.com",
+	"cn-apple-pay-gateway-sh-pod1.apple.com",
+	"cn-apple-pay-gateway-sh-pod2.apple.com",
+	"cn-apple-pay-gateway-sh-pod3.apple.com",
+	"cn-apple-pay-gateway-tj-pod1.apple.com",
+	"cn-apple-pay-gateway-tj-pod2.apple.com",
+	"cn-apple-pay-gateway-tj-pod3.apple.com",
+	"apple-pay-gateway-cert.apple.com",
+	"cn-apple-pay-gateway-cert.apple.com",
+}
+
+// DefaultMaxBodySize limits the size of the requests read by a handler.
+const DefaultMaxBodySize = 4 << 10
+
+var (
+	errValidationURL = errors.New("the validation url is not an Apple Pay url")
+	errInvalidToken  = errors.New("the payment token is not valid json")
+)
+
+// Options configures a handler.
+type Options struct {
+	// Domain is the domain showing the payment sheet, it must be
+	// registered for Apple Pay in the Mollie Dashboard. Defaults to the
+	// host of the incoming request.
+	Domain string
+	// Hosts replaces the allowed validation URL hosts.
+	Hosts []string
+	// MaxBodySize limits the size of the request body.
+	MaxBodySize int64
+	// OnError is called with the errors returned by Mollie, they are not
+	// exposed to the browser.
+	OnError func(r *http.Request, err error)
+}
+
+// Handler receives the validation URL posted by the browser as a JSON
+// object, {"validationUrl": "..."}, and responds with the merchant
+// session returned by Mollie, to be passed to completeMerchantValidation
+// as is.
+type Handler struct {
+	client  *mollie.Client
+	domain  string
+	hosts   map[string]bool
+	maxBody int64
+	onError func(r *http.Request, err error)
+}
+
+// NewHandler creates a merchant validation handler, a nil options value
+// uses the defaults.
+func NewHandler(client *mollie.Client, opts *Options) *Handler {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if len(o.Hosts) == 0 {
+		o.Hosts = Hosts
+	}
+
+	if o.MaxBodySize <= 0 {
+		o.MaxBodySize = DefaultMaxBodySize
+	}
+
+	h := &Handler{
+		client:  client,
+		domain:  o.Domain,
+		hosts:   map[string]bool{},
+		maxBody: o.MaxBodySize,
+		onError: o.OnError,
+	}
+
+	for _, host := range o.Hosts {
+		h.hosts[strings.ToLower(host)] = true
+	}
+
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+		return
+	}
+
+	var req mollie.ApplePaymentSessionRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, h.maxBody)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+
+		return
+	}
+
+	if err := h.validate(req.ValidationURL); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// the domain is never taken from the body.
+	req.Domain = h.domain
+	if req.Domain == "" {
+		req.Domain = hostname(r.Host)
+	}
+
+	res, _, err := h.client.Miscellaneous.ApplePaymentSession(r.Context(), &req)
+	if err != nil {
+		if h.onError != nil {
+			h.onError(r, err)
+		}
+
+		writeError(w, http.StatusBadGateway, "the merchant session could not be created")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, res.Body)
+}
+
+func (h *Handler) validate(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return fmt.Errorf("applepay_error: %w", errValidationURL)
+	}
+
+	if !h.hosts[strings.ToLower(u.Hostname())] {
+		return fmt.Errorf("applepay_error: %w", errValidationURL)
+	}
+
+	return nil
+}
+
+// ValidateURL checks that a validation URL uses https and one of Hosts.
+func ValidateURL(validationURL string) error {
+	return NewHandler(nil, nil).validate(validationURL)
+}
+
+// Payment creates an Apple Pay payment using the token returned by Apple
+// when the shopper authorized the payment, the JSON encoded token member
+// of the ApplePayPayment object.
+func Payment(ctx context.Context, client *mollie.Client, token string, p mollie.Payment, opts *mollie.PaymentOptions) (*mollie.Payment, error) {
+	if !json.Valid([]byte(token)) {
+		return nil, fmt.Errorf("applepay_error: %w", errInvalidToken)
+	}
+
+	p.Method = mollie.ApplePay
+	p.ApplePayPaymentToken = token
+
+	_, np, err := client.Payments.Create(ctx, p, opts)
+	if err != nil {
+		return nil, fmt.Errorf("applepay_error: %w", err)
+	}
+
+	return np, nil
+}
+
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+
+	return host
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
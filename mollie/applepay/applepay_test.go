@@ -0,0 +1,138 @@
+package applepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func post(h http.Handler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "https://shop.example.org:8443/apple-pay/session", strings.NewReader(body))
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	var got mollie.ApplePaymentSessionRequest
+
+	client := mollietest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/wallets/applepay/sessions", r.URL.Path)
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(testdata.ApplePaySessionResponse))
+	}))
+
+	t.Run("forwards the session", func(t *testing.T) {
+		rec := post(NewHandler(client, &Options{Domain: "pay.example.org"}),
+			`{"validationUrl": "https://apple-pay-gateway-cert.apple.com/paymentservices/paymentSession", "domain": "evil.example.com"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, testdata.ApplePaySessionResponse, rec.Body.String())
+		assert.Equal(t, "pay.example.org", got.Domain)
+		assert.Equal(t, "https://apple-pay-gateway-cert.apple.com/paymentservices/paymentSession", got.ValidationURL)
+
+		var s mollie.ApplePaymentSession
+		require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &s))
+		assert.Equal(t, "BD62FEB196874511C22DB28A9E14A89E3534C93194F73EA417EC566368D391EB", s.MerchantID)
+	})
+
+	t.Run("defaults to the request host", func(t *testing.T) {
+		rec := post(NewHandler(client, nil), `{"validationUrl": "https://apple-pay-gateway.apple.com/paymentservices/startSession"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "shop.example.org", got.Domain)
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHandler(client, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+	})
+
+	t.Run("rejects invalid bodies", func(t *testing.T) {
+		rec := post(NewHandler(client, &Options{MaxBodySize: 10}), `{"validationUrl": "https://apple-pay-gateway.apple.com/"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	for _, u := range []string{
+		"https://apple-pay-gateway.apple.com.evil.example.com/paymentSession",
+		"http://apple-pay-gateway.apple.com/paymentSession",
+		"https://apple-pay-gateway.apple.com:8443/paymentSession",
+		"https://user@apple-pay-gateway.apple.com/paymentSession",
+		"https://169.254.169.254/latest/meta-data",
+		"",
+	} {
+		t.Run("rejects "+u, func(t *testing.T) {
+			rec := post(NewHandler(client, nil), `{"validationUrl": "`+u+`"}`)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "not an Apple Pay url")
+		})
+	}
+}
+
+func TestHandler_MollieError(t *testing.T) {
+	client := mollietest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"status": 422, "title": "Unprocessable Entity", "detail": "The domain is not registered"}`))
+	}))
+
+	var reported error
+
+	h := NewHandler(client, &Options{
+		Hosts:   []string{"apple-pay-gateway.example.net"},
+		OnError: func(r *http.Request, err error) { reported = err },
+	})
+
+	rec := post(h, `{"validationUrl": "https://apple-pay-gateway.example.net/paymentSession"}`)
+
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "registered")
+	require.NotNil(t, reported)
+	assert.Contains(t, reported.Error(), "The domain is not registered")
+}
+
+func TestValidateURL(t *testing.T) {
+	assert.Nil(t, ValidateURL("https://cn-apple-pay-gateway-sh-pod2.apple.com/paymentservices/paymentSession"))
+	assert.True(t, errors.Is(ValidateURL("https://example.com"), errValidationURL))
+}
+
+func TestPayment(t *testing.T) {
+	var body map[string]interface{}
+
+	client := mollietest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/payments", r.URL.Path)
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+	}))
+
+	token := `{"paymentData": {"version": "EC_v1", "data": "..."}, "transactionIdentifier": "abc"}`
+
+	p, err := Payment(context.Background(), client, token, mollie.Payment{
+		Amount:      &mollie.Amount{Currency: "EUR", Value: "10.00"},
+		Description: "Order 12345",
+	}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, "tr_WDqYK6vllg", p.ID)
+	assert.Equal(t, "applepay", body["method"])
+	assert.Equal(t, token, body["applePayPaymentToken"])
+
+	_, err = Payment(context.Background(), client, "not json", mollie.Payment{}, nil)
+	assert.True(t, errors.Is(err, errInvalidToken))
+}
@@ -0,0 +1,322 @@
+// Package bulk runs an API operation over many inputs, such as refunding
+// a list of payments or updating the metadata of every customer, with
+// bounded concurrency, rate limiting and retries.
+//
+// Every item is identified by a key, it is used to build the idempotency
+// key of the requests sent for the item and to record the completed items
+// in a Checkpoint, so an interrupted run can be resumed without repeating
+// the work already done.
+//
+//	refund := func(ctx context.Context, id string) (*mollie.Refund, error) {
+//		_, r, err := client.Refunds.Create(ctx, id, mollie.Refund{Description: "Recall"}, nil)
+//		return r, err
+//	}
+//
+//	runner := bulk.New(refund, func(id string) string { return id }, &bulk.Options{
+//		Job:         "recall-2022-06",
+//		Concurrency: 8,
+//		Rate:        20,
+//		Retries:     3,
+//		Checkpoint:  checkpoint,
+//	})
+//
+//	report, err := runner.Run(ctx, bulk.Slice(paymentIDs))
+//
+// Operations must pass the context they receive to the client, it carries
+// the idempotency key of the item.
+package bulk
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Default runner settings.
+const (
+	DefaultConcurrency = 4
+	DefaultBackoff     = time.Second
+)
+
+// Operation performs the work for a single input.
+type Operation[In, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Source provides the inputs of a run, mollie.Iterator implements it so
+// the resources of a list endpoint can be processed as they are fetched.
+type Source[T any] interface {
+	Next(ctx context.Context) bool
+	Value() T
+	Err() error
+}
+
+// Options configures a runner.
+type Options struct {
+	// Job identifies the run and prefixes the idempotency keys, a random
+	// value is used when empty. Set it to the value of the interrupted run
+	// when resuming, so the requests in flight at the time of the
+	// interruption are not executed twice.
+	Job string
+	// Concurrency limits the operations running at the same time.
+	Concurrency int
+	// Rate limits the operations started per second, retries included,
+	// zero disables the limit.
+	Rate float64
+	// Retries is the amount of times a failed operation is repeated when
+	// Retry reports its error as temporary.
+	Retries int
+	// Backoff is the wait before the first retry, it doubles on every
+	// following one.
+	Backoff time.Duration
+	// Retry decides if an error is temporary, defaults to Retryable.
+	Retry func(err error) bool
+	// Checkpoint records the completed items, items already recorded are
+	// skipped.
+	Checkpoint Checkpoint
+}
+
+// Runner applies an operation to the inputs of a source.
+type Runner[In, Out any] struct {
+	op   Operation[In, Out]
+	key  func(In) string
+	opts Options
+	now  func() time.Time
+}
+
+// New creates a runner applying op to every input, key identifies an input
+// and must be unique within the run. A nil options value uses the defaults.
+func New[In, Out any](op Operation[In, Out], key func(In) string, opts *Options) *Runner[In, Out] {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Job == "" {
+		o.Job = randomJob()
+	}
+
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+
+	if o.Backoff <= 0 {
+		o.Backoff = DefaultBackoff
+	}
+
+	if o.Retry == nil {
+		o.Retry = Retryable
+	}
+
+	return &Runner[In, Out]{op: op, key: key, opts: o, now: time.Now}
+}
+
+type item[In any] struct {
+	index int
+	key   string
+	in    In
+}
+
+// Run applies the operation to every input of src and waits for all of
+// them to complete.
+//
+// Failed items are recorded in the report, an error is only returned when
+// the source or the checkpoint fail or ctx is done, the report then holds
+// the items completed so far.
+func (r *Runner[In, Out]) Run(ctx context.Context, src Source[In]) (*Report[Out], error) {
+	report := &Report[Out]{Job: r.opts.Job, StartedAt: r.now()}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var limit <-chan time.Time
+
+	if r.opts.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / r.opts.Rate))
+		defer ticker.Stop()
+
+		limit = ticker.C
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		runErr error
+	)
+
+	fail := func(err error) {
+		mu.Lock()
+		if runErr == nil {
+			runErr = err
+		}
+		mu.Unlock()
+
+		cancel()
+	}
+
+	items := make(chan item[In])
+
+	for i := 0; i < r.opts.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for it := range items {
+				res := r.process(runCtx, it, limit)
+
+				if res.Err == nil && r.opts.Checkpoint != nil {
+					if err := r.opts.Checkpoint.Mark(ctx, it.key); err != nil {
+						fail(fmt.Errorf("bulk_error: checkpoint: %w", err))
+					}
+				}
+
+				mu.Lock()
+				report.Results = append(report.Results, res)
+				mu.Unlock()
+			}
+		}()
+	}
+
+loop:
+	for index := 0; src.Next(runCtx); index++ {
+		in := src.Value()
+		key := r.key(in)
+
+		if r.opts.Checkpoint != nil {
+			done, err := r.opts.Checkpoint.Done(runCtx, key)
+			if err != nil {
+				fail(fmt.Errorf("bulk_error: checkpoint: %w", err))
+
+				break
+			}
+
+			if done {
+				mu.Lock()
+				report.Results = append(report.Results, Result[Out]{Index: index, Key: key, Skipped: true})
+				mu.Unlock()
+
+				continue
+			}
+		}
+
+		select {
+		case items <- item[In]{index, key, in}:
+		case <-runCtx.Done():
+			break loop
+		}
+	}
+
+	close(items)
+	wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Index < report.Results[j].Index
+	})
+
+	report.FinishedAt = r.now()
+
+	err := runErr
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	if err == nil {
+		err = src.Err()
+	}
+
+	return report, err
+}
+
+// process runs the operation for an item, retrying temporary failures.
+func (r *Runner[In, Out]) process(ctx context.Context, it item[In], limit <-chan time.Time) Result[Out] {
+	res := Result[Out]{Index: it.index, Key: it.key}
+
+	ctx = mollie.WithIdempotencyKey(ctx, fmt.Sprintf("%s-%s", r.opts.Job, it.key))
+	backoff := r.opts.Backoff
+
+	for {
+		if limit != nil {
+			select {
+			case <-limit:
+			case <-ctx.Done():
+				res.Err = ctx.Err()
+
+				return res
+			}
+		}
+
+		res.Attempts++
+		res.Value, res.Err = r.op(ctx, it.in)
+
+		if res.Err == nil || res.Attempts > r.opts.Retries || !r.opts.Retry(res.Err) {
+			return res
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return res
+		}
+	}
+}
+
+// Retryable reports whether err is temporary: rate limited requests,
+// server errors and failures reaching the API.
+func Retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var be *mollie.BaseError
+	if errors.As(err, &be) {
+		return be.Status == http.StatusTooManyRequests || be.Status >= http.StatusInternalServerError
+	}
+
+	var ue *url.Error
+
+	return errors.As(err, &ue)
+}
+
+type sliceSource[T any] struct {
+	items   []T
+	current T
+}
+
+// Slice returns a source over the given inputs.
+func Slice[T any](items []T) Source[T] {
+	return &sliceSource[T]{items: items}
+}
+
+func (s *sliceSource[T]) Next(ctx context.Context) bool {
+	if len(s.items) == 0 || ctx.Err() != nil {
+		return false
+	}
+
+	s.current, s.items = s.items[0], s.items[1:]
+
+	return true
+}
+
+func (s *sliceSource[T]) Value() T {
+	return s.current
+}
+
+func (s *sliceSource[T]) Err() error {
+	return nil
+}
+
+func randomJob() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,249 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func identity(s string) string { return s }
+
+func TestRunner_Run(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		keys  = map[string]int{}
+		calls int32
+	)
+
+	client := mollietest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get(mollie.IdempotencyHeader)]++
+		mu.Unlock()
+
+		// every first attempt for tr_flaky is rejected by a busy server.
+		if r.URL.Path == "/v2/payments/tr_flaky/refunds" && atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status": 503, "title": "Service Unavailable", "detail": "Try again later"}`))
+			return
+		}
+
+		if r.URL.Path == "/v2/payments/tr_refunded/refunds" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"status": 422, "title": "Unprocessable Entity", "detail": "The payment has been refunded"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(testdata.GetRefundResponse))
+	}))
+
+	refund := func(ctx context.Context, id string) (*mollie.Refund, error) {
+		_, r, err := client.Refunds.Create(ctx, id, mollie.Refund{}, nil)
+		return r, err
+	}
+
+	runner := New(refund, identity, &Options{Job: "recall", Concurrency: 2, Retries: 2, Backoff: time.Millisecond})
+
+	report, err := runner.Run(context.Background(), Slice([]string{"tr_ok", "tr_flaky", "tr_refunded"}))
+	require.Nil(t, err)
+	require.Len(t, report.Results, 3)
+
+	assert.Equal(t, "recall", report.Job)
+	assert.Equal(t, 2, report.Succeeded())
+	assert.Equal(t, 0, report.Skipped())
+
+	assert.Equal(t, "tr_ok", report.Results[0].Key)
+	assert.Equal(t, "re_4qqhO89gsT", report.Results[0].Value.ID)
+	assert.Equal(t, 2, report.Results[1].Attempts)
+	assert.Nil(t, report.Results[1].Err)
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, 2, failed[0].Index)
+	assert.Equal(t, 1, failed[0].Attempts)
+	assert.EqualError(t, failed[0].Err, "422 Unprocessable Entity: The payment has been refunded")
+
+	// retries reuse the idempotency key of the item.
+	assert.Equal(t, map[string]int{"recall-tr_ok": 1, "recall-tr_flaky": 2, "recall-tr_refunded": 1}, keys)
+}
+
+func TestRunner_Concurrency(t *testing.T) {
+	var running, peak int32
+
+	op := func(ctx context.Context, n int) (int, error) {
+		cur := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if cur <= p || atomic.CompareAndSwapInt32(&peak, p, cur) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		return n * 2, nil
+	}
+
+	inputs := make([]int, 20)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	report, err := New(op, func(n int) string { return fmt.Sprint(n) }, &Options{Concurrency: 3}).
+		Run(context.Background(), Slice(inputs))
+	require.Nil(t, err)
+
+	assert.Equal(t, 20, report.Succeeded())
+	assert.LessOrEqual(t, peak, int32(3))
+	assert.Equal(t, 38, report.Results[19].Value)
+	assert.NotEmpty(t, report.Job)
+}
+
+func TestRunner_Rate(t *testing.T) {
+	op := func(ctx context.Context, n int) (int, error) { return n, nil }
+
+	start := time.Now()
+
+	report, err := New(op, func(n int) string { return fmt.Sprint(n) }, &Options{Concurrency: 5, Rate: 200}).
+		Run(context.Background(), Slice([]int{1, 2, 3, 4, 5}))
+	require.Nil(t, err)
+
+	assert.Equal(t, 5, report.Succeeded())
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestRunner_Checkpoint(t *testing.T) {
+	checkpoint := NewMemoryCheckpoint()
+	fail := true
+
+	op := func(ctx context.Context, id string) (string, error) {
+		if id == "cst_2" && fail {
+			return "", errors.New("invalid metadata")
+		}
+
+		return id, nil
+	}
+
+	runner := New(op, identity, &Options{Checkpoint: checkpoint})
+	inputs := []string{"cst_1", "cst_2", "cst_3"}
+
+	report, err := runner.Run(context.Background(), Slice(inputs))
+	require.Nil(t, err)
+	assert.Equal(t, 2, report.Succeeded())
+	assert.Len(t, report.Failed(), 1)
+
+	fail = false
+
+	report, err = runner.Run(context.Background(), Slice(inputs))
+	require.Nil(t, err)
+	assert.Equal(t, 1, report.Succeeded())
+	assert.Equal(t, 2, report.Skipped())
+	assert.True(t, report.Results[0].Skipped)
+	assert.Equal(t, "cst_2", report.Results[1].Value)
+}
+
+func TestRunner_Errors(t *testing.T) {
+	op := func(ctx context.Context, id string) (string, error) { return id, nil }
+
+	t.Run("the checkpoint fails", func(t *testing.T) {
+		_, err := New(op, identity, &Options{Checkpoint: failingCheckpoint{}}).
+			Run(context.Background(), Slice([]string{"a"}))
+		assert.EqualError(t, err, "bulk_error: checkpoint: unavailable")
+	})
+
+	t.Run("the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		report, err := New(op, identity, nil).Run(ctx, Slice([]string{"a", "b"}))
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, report.Results)
+	})
+
+	t.Run("the source fails", func(t *testing.T) {
+		client := mollietest.NewClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+
+		_, err := New(func(ctx context.Context, c *mollie.Customer) (string, error) { return c.ID, nil },
+			func(c *mollie.Customer) string { return c.ID }, nil).
+			Run(context.Background(), client.Customers.Iterate(nil))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limited", &mollie.BaseError{Status: http.StatusTooManyRequests}, true},
+		{"server error", &mollie.BaseError{Status: http.StatusBadGateway}, true},
+		{"validation error", &mollie.BaseError{Status: http.StatusUnprocessableEntity}, false},
+		{"network error", fmt.Errorf("httperror: %w", &url.Error{Op: "Post", Err: errors.New("connection reset")}), true},
+		{"canceled", fmt.Errorf("httperror: %w", &url.Error{Op: "Post", Err: context.Canceled}), false},
+		{"other error", errors.New("invalid character"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, Retryable(c.err))
+		})
+	}
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recall.checkpoint")
+	ctx := context.Background()
+
+	fc, err := OpenFileCheckpoint(path)
+	require.Nil(t, err)
+
+	require.Nil(t, fc.Mark(ctx, "tr_1"))
+	require.Nil(t, fc.Mark(ctx, "tr_2"))
+	require.Nil(t, fc.Mark(ctx, "tr_1"))
+	assert.ErrorIs(t, fc.Mark(ctx, "tr_\n3"), errInvalidKey)
+	require.Nil(t, fc.Close())
+
+	fc, err = OpenFileCheckpoint(path)
+	require.Nil(t, err)
+	defer fc.Close()
+
+	done, err := fc.Done(ctx, "tr_2")
+	require.Nil(t, err)
+	assert.True(t, done)
+
+	done, _ = fc.Done(ctx, "tr_3")
+	assert.False(t, done)
+
+	content, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Equal(t, "tr_1\ntr_2\n", string(content))
+}
+
+type failingCheckpoint struct{}
+
+func (failingCheckpoint) Done(ctx context.Context, key string) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func (failingCheckpoint) Mark(ctx context.Context, key string) error {
+	return errors.New("unavailable")
+}
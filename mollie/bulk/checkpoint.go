@@ -0,0 +1,120 @@
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var errInvalidKey = errors.New("checkpoint keys can't be empty or contain line breaks")
+
+// Checkpoint records the items completed by a run so it can be resumed.
+type Checkpoint interface {
+	// Done reports whether the item with the given key was completed.
+	Done(ctx context.Context, key string) (bool, error)
+	// Mark records the item with the given key as completed.
+	Mark(ctx context.Context, key string) error
+}
+
+// MemoryCheckpoint is a Checkpoint keeping the completed keys in memory,
+// it allows repeating the failed items of a run within the same process.
+type MemoryCheckpoint struct {
+	mu   sync.Mutex
+	done map[string]bool
+}
+
+// NewMemoryCheckpoint returns an empty memory checkpoint.
+func NewMemoryCheckpoint() *MemoryCheckpoint {
+	return &MemoryCheckpoint{done: map[string]bool{}}
+}
+
+// Done implements Checkpoint.
+func (m *MemoryCheckpoint) Done(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.done[key], nil
+}
+
+// Mark implements Checkpoint.
+func (m *MemoryCheckpoint) Mark(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.done[key] = true
+
+	return nil
+}
+
+// FileCheckpoint is a Checkpoint appending the completed keys to a file,
+// one per line, so a run can be resumed after the process exits.
+type FileCheckpoint struct {
+	mu   sync.Mutex
+	f    *os.File
+	done map[string]bool
+}
+
+// OpenFileCheckpoint loads the keys recorded in the file at path, creating
+// it when missing. The checkpoint must be closed once the run completes.
+func OpenFileCheckpoint(path string) (*FileCheckpoint, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint_error: %w", err)
+	}
+
+	done := map[string]bool{}
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if key := sc.Text(); key != "" {
+			done[key] = true
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		_ = f.Close()
+
+		return nil, fmt.Errorf("checkpoint_error: %w", err)
+	}
+
+	return &FileCheckpoint{f: f, done: done}, nil
+}
+
+// Done implements Checkpoint.
+func (fc *FileCheckpoint) Done(ctx context.Context, key string) (bool, error) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	return fc.done[key], nil
+}
+
+// Mark implements Checkpoint.
+func (fc *FileCheckpoint) Mark(ctx context.Context, key string) error {
+	if key == "" || strings.ContainsAny(key, "\r\n") {
+		return fmt.Errorf("checkpoint_error: %q: %w", key, errInvalidKey)
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if fc.done[key] {
+		return nil
+	}
+
+	if _, err := fc.f.WriteString(key + "\n"); err != nil {
+		return fmt.Errorf("checkpoint_error: %w", err)
+	}
+
+	fc.done[key] = true
+
+	return nil
+}
+
+// Close closes the checkpoint file.
+func (fc *FileCheckpoint) Close() error {
+	return fc.f.Close()
+}
@@ -0,0 +1,64 @@
+package bulk
+
+import "time"
+
+// Result is the outcome of the operation for one input.
+type Result[Out any] struct {
+	// Index is the position of the input in the source.
+	Index int
+	Key   string
+	Value Out
+	Err   error
+	// Attempts counts the times the operation ran, retries included.
+	Attempts int
+	// Skipped items were completed by a previous run.
+	Skipped bool
+}
+
+// Report collects the results of a run, sorted by input position.
+type Report[Out any] struct {
+	Job        string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Results    []Result[Out]
+}
+
+// Succeeded returns the amount of items processed without error during
+// the run.
+func (r *Report[Out]) Succeeded() int {
+	n := 0
+
+	for _, res := range r.Results {
+		if !res.Skipped && res.Err == nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Skipped returns the amount of items completed by a previous run.
+func (r *Report[Out]) Skipped() int {
+	n := 0
+
+	for _, res := range r.Results {
+		if res.Skipped {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Failed returns the results of the items whose operation failed.
+func (r *Report[Out]) Failed() []Result[Out] {
+	var failed []Result[Out]
+
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+
+	return failed
+}
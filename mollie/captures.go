@@ -32,6 +32,8 @@ type Capture struct {
 	SettlementID     string       `json:"settlementId,omitempty"`
 	CreatedAt        *time.Time   `json:"createdAt,omitempty"`
 	Links            CaptureLinks `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // CapturesList describes a list of captures.
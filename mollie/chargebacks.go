@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -17,6 +18,11 @@ type Chargeback struct {
 	ReversedAt       *time.Time      `json:"reversedAt,omitempty"`
 	PaymentID        string          `json:"paymentId,omitempty"`
 	Links            ChargebackLinks `json:"_links,omitempty"`
+	Embedded         struct {
+		Payment *Payment `json:"payment,omitempty"`
+	} `json:"_embedded,omitempty"`
+
+	*rawJSON
 }
 
 // ChargebackLinks describes all the possible links to be returned with
@@ -28,19 +34,43 @@ type ChargebackLinks struct {
 	Documentation *URL `json:"documentation,omitempty"`
 }
 
+// ChargebackEmbeds is a set of resources embedded in chargebacks, only
+// EmbedPayment is supported.
+type ChargebackEmbeds []EmbedValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (e ChargebackEmbeds) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, e, EmbedPayment)
+}
+
+// Has reports whether the set contains the value.
+func (e ChargebackEmbeds) Has(value EmbedValue) bool {
+	return contains(e, value)
+}
+
+// With returns the set with the value added when missing.
+func (e ChargebackEmbeds) With(value EmbedValue) ChargebackEmbeds {
+	return with(e, value)
+}
+
 // ChargebackOptions describes chargeback endpoint valid query string parameters.
 type ChargebackOptions struct {
-	Include string `url:"include,omitempty"`
-	Embed   string `url:"embed,omitempty"`
+	// Deprecated: the chargebacks endpoints take no include parameter,
+	// the field is ignored.
+	Include string           `url:"-"`
+	Embed   ChargebackEmbeds `url:"embed,omitempty"`
 }
 
 // ChargebacksListOptions describes list chargebacks endpoint valid query string parameters.
 type ChargebacksListOptions struct {
-	From      string `url:"from,omitempty"`
-	Limit     int    `url:"limit,omitempty"`
-	Include   string `url:"include,omitempty"`
-	Embed     string `url:"embed,omitempty"`
-	ProfileID string `url:"profileId,omitempty"`
+	From      string           `url:"from,omitempty"`
+	Limit     int              `url:"limit,omitempty"`
+	Embed     ChargebackEmbeds `url:"embed,omitempty"`
+	ProfileID string           `url:"profileId,omitempty"`
+
+	// Deprecated: the chargebacks endpoints take no include parameter,
+	// the field is ignored.
+	Include string `url:"-"`
 }
 
 // ChargebacksList describes how a list of chargebacks will be retrieved by Mollie.
@@ -81,6 +111,25 @@ func (cs *ChargebacksService) List(ctx context.Context, options *ChargebacksList
 	return cs.list(ctx, "v2/chargebacks", options)
 }
 
+// Iterate returns an iterator over all the chargebacks of your account or
+// organization.
+//
+// See: https://docs.mollie.com/reference/v2/chargebacks-api/list-chargebacks
+func (cs *ChargebacksService) Iterate(options *ChargebacksListOptions) *Iterator[*Chargeback] {
+	return newListIterator[*Chargeback](cs.client, func(from string) (string, interface{}) {
+		opts := ChargebacksListOptions{}
+		if options != nil {
+			opts = *options
+		}
+
+		if from != "" {
+			opts.From = from
+		}
+
+		return "v2/chargebacks", &opts
+	})
+}
+
 // ListForPayment retrieves a list of chargebacks associated with a single payment.
 //
 // See: https://docs.mollie.com/reference/v2/chargebacks-api/list-chargebacks
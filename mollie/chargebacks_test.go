@@ -366,6 +366,22 @@ func (cbs *chargebacksSuite) TestChargebacksService_ListForPayment() {
 	}
 }
 
+func (cs *chargebacksSuite) TestChargebacksService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/chargebacks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(cs.T(), r, "GET")
+		testQuery(cs.T(), r, "profileId=pfl_QkEhN94Ba&testmode=true")
+		_, _ = w.Write([]byte(testdata.ListChargebacksResponse))
+	})
+
+	chargebacks, err := tClient.Chargebacks.Iterate(&ChargebacksListOptions{ProfileID: "pfl_QkEhN94Ba"}).All(context.Background())
+	cs.Nil(err)
+	cs.NotEmpty(chargebacks)
+	cs.Equal("chb_n9z0tp", chargebacks[0].ID)
+}
+
 func TestChargebacksService(t *testing.T) {
 	suite.Run(t, new(chargebacksSuite))
 }
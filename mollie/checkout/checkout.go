@@ -0,0 +1,152 @@
+// Package checkout implements the hosted checkout flow for net/http
+// applications.
+//
+// A Handler creates a payment or an order from the request, stores the
+// link between the reference of the application and the Mollie resource
+// and redirects the shopper to the checkout. When the shopper comes back
+// on the redirect URL, a ReturnHandler resolves the resource and renders
+// the outcome:
+//
+//	store := checkout.NewMemoryStore()
+//
+//	http.Handle("/pay", checkout.NewHandler(client, store, func(r *http.Request) (*checkout.Checkout, error) {
+//		order := loadCart(r)
+//
+//		return &checkout.Checkout{
+//			Reference: order.ID,
+//			Payment: &mollie.Payment{
+//				Amount:      order.Total,
+//				Description: "Order " + order.ID,
+//				RedirectURL: "https://shop.example.org/return",
+//			},
+//		}, nil
+//	}, nil))
+//
+//	http.Handle("/return", checkout.NewReturnHandler(client, store, func(w http.ResponseWriter, r *http.Request, res *checkout.Result) {
+//		switch res.Outcome {
+//		case checkout.Succeeded:
+//			renderThanks(w, res.Session.Reference)
+//		case checkout.Pending:
+//			renderPending(w)
+//		default:
+//			renderRetry(w, res.Outcome, res.FailureReason)
+//		}
+//	}, nil))
+package checkout
+
+import (
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Kind describes the resource created for a checkout.
+type Kind string
+
+// Resources supporting the hosted checkout.
+const (
+	Payment Kind = "payment"
+	Order   Kind = "order"
+)
+
+// Outcome is the result of a checkout as shown to the shopper.
+type Outcome string
+
+// Possible outcomes.
+const (
+	// Succeeded checkouts are paid or authorized.
+	Succeeded Outcome = "succeeded"
+	// Pending checkouts wait for the payment to complete, like bank
+	// transfers or payments still processed by the bank.
+	Pending  Outcome = "pending"
+	Failed   Outcome = "failed"
+	Canceled Outcome = "canceled"
+	Expired  Outcome = "expired"
+)
+
+// Checkout describes the resource to create, either Payment or Order
+// must be set. Reference identifies the checkout in the application,
+// like the ID of the cart or order.
+type Checkout struct {
+	Reference      string
+	Payment        *mollie.Payment
+	PaymentOptions *mollie.PaymentOptions
+	Order          *mollie.Order
+	OrderOptions   *mollie.OrderOptions
+}
+
+// Session links the reference of a checkout to the Mollie resource
+// created for it.
+type Session struct {
+	Reference string
+	Kind      Kind
+	ID        string
+	CreatedAt time.Time
+}
+
+// Result is the state of a checkout when the shopper returns, Payment is
+// the last payment made for it and is nil for orders without payments.
+type Result struct {
+	Session       *Session
+	Outcome       Outcome
+	FailureReason mollie.FailureReason
+	Payment       *mollie.Payment
+	Order         *mollie.Order
+}
+
+// PaymentOutcome maps the status of a payment to an outcome.
+func PaymentOutcome(p *mollie.Payment) Outcome {
+	switch p.Status {
+	case mollie.PaymentPaid, mollie.PaymentAuthorized:
+		return Succeeded
+	case mollie.PaymentFailed:
+		return Failed
+	case mollie.PaymentCanceled:
+		return Canceled
+	case mollie.PaymentExpired:
+		return Expired
+	default:
+		return Pending
+	}
+}
+
+// OrderOutcome maps the status of an order to an outcome. Orders stay
+// created when their payment fails, the last payment decides the outcome
+// when it is embedded.
+func OrderOutcome(o *mollie.Order) Outcome {
+	switch o.Status {
+	case mollie.Paid, mollie.Authorized, mollie.Shipping, mollie.Completed:
+		return Succeeded
+	case mollie.Canceled:
+		return Canceled
+	case mollie.Expired:
+		return Expired
+	}
+
+	if p := lastPayment(o); p != nil {
+		return PaymentOutcome(p)
+	}
+
+	return Pending
+}
+
+// FailureReason returns the failure reason of a payment, if any.
+func FailureReason(p *mollie.Payment) mollie.FailureReason {
+	if p == nil || p.Details == nil {
+		return ""
+	}
+
+	return p.Details.FailureReason
+}
+
+func lastPayment(o *mollie.Order) *mollie.Payment {
+	var last *mollie.Payment
+
+	for _, p := range o.Embedded.Payments {
+		if last == nil || (p.CreatedAt != nil && last.CreatedAt != nil && p.CreatedAt.After(*last.CreatedAt)) {
+			last = p
+		}
+	}
+
+	return last
+}
@@ -0,0 +1,247 @@
+package checkout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct {
+	created map[string]interface{}
+	status  string
+	details string
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/payments":
+		_ = json.NewDecoder(r.Body).Decode(&s.created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{
+            "resource": "payment", "id": "tr_WDqYK6vllg", "status": "open",
+            "_links": {"checkout": {"href": "https://www.mollie.com/payscreen/select-method/WDqYK6vllg", "type": "text/html"}}
+        }`))
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/orders":
+		_ = json.NewDecoder(r.Body).Decode(&s.created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{
+            "resource": "order", "id": "ord_pbjz8x", "status": "created",
+            "_links": {"checkout": {"href": "https://www.mollie.com/payscreen/order/checkout/pbjz8x", "type": "text/html"}}
+        }`))
+	case r.URL.Path == "/v2/payments/tr_WDqYK6vllg":
+		_, _ = fmt.Fprintf(w, `{"resource": "payment", "id": "tr_WDqYK6vllg", "status": %q, "details": %s}`, s.status, s.details)
+	case r.URL.Path == "/v2/orders/ord_pbjz8x":
+		if r.URL.Query().Get("embed") != "payments" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"resource": "order", "id": "ord_pbjz8x", "status": "created", "_embedded": {"payments": [
+            {"resource": "payment", "id": "tr_1", "status": "failed", "createdAt": "2022-06-01T10:00:00+00:00"},
+            {"resource": "payment", "id": "tr_2", "status": %q, "createdAt": "2022-06-01T10:05:00+00:00", "details": %s}
+        ]}}`, s.status, s.details)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status": 404, "title": "Not Found", "detail": "No resource found"}`))
+	}
+}
+
+func setup(t *testing.T) (*mollie.Client, *server) {
+	t.Helper()
+
+	s := &server{status: "open", details: "null"}
+
+	return mollietest.NewClient(t, s), s
+}
+
+func TestHandler_Payment(t *testing.T) {
+	client, s := setup(t)
+	store := NewMemoryStore()
+
+	h := NewHandler(client, store, func(r *http.Request) (*Checkout, error) {
+		return &Checkout{
+			Reference: r.FormValue("cart"),
+			Payment: &mollie.Payment{
+				Amount:      &mollie.Amount{Currency: "EUR", Value: "24.95"},
+				Description: "Order 12345",
+				RedirectURL: "https://shop.example.org/return?lang=nl",
+			},
+		}, nil
+	}, nil)
+	h.now = func() time.Time { return time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC) }
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/pay", strings.NewReader("cart=12345"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "https://www.mollie.com/payscreen/select-method/WDqYK6vllg", rec.Header().Get("Location"))
+	assert.Equal(t, "https://shop.example.org/return?lang=nl&reference=12345", s.created["redirectUrl"])
+
+	session, err := store.Get(req.Context(), "12345")
+	require.Nil(t, err)
+	assert.Equal(t, &Session{
+		Reference: "12345",
+		Kind:      Payment,
+		ID:        "tr_WDqYK6vllg",
+		CreatedAt: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+	}, session)
+}
+
+func TestHandler_Order(t *testing.T) {
+	client, s := setup(t)
+	store := NewMemoryStore()
+
+	h := NewHandler(client, store, func(r *http.Request) (*Checkout, error) {
+		return &Checkout{
+			Reference: "cart-1",
+			Order:     &mollie.Order{OrderNumber: "1337", RedirectURL: "https://shop.example.org/return"},
+		}, nil
+	}, &Options{ReferenceParam: "cart"})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pay", nil))
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "https://www.mollie.com/payscreen/order/checkout/pbjz8x", rec.Header().Get("Location"))
+	assert.Equal(t, "https://shop.example.org/return?cart=cart-1", s.created["redirectUrl"])
+
+	session, err := store.Get(context.Background(), "cart-1")
+	require.Nil(t, err)
+	assert.Equal(t, Order, session.Kind)
+	assert.Equal(t, "ord_pbjz8x", session.ID)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	client, _ := setup(t)
+
+	var got error
+
+	onError := func(w http.ResponseWriter, r *http.Request, err error) {
+		got = err
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	cases := []struct {
+		name  string
+		build Builder
+		want  error
+	}{
+		{"builder errors", func(r *http.Request) (*Checkout, error) { return nil, errors.New("empty cart") }, nil},
+		{"no reference", func(r *http.Request) (*Checkout, error) { return &Checkout{Payment: &mollie.Payment{}}, nil }, errNoReference},
+		{"no resource", func(r *http.Request) (*Checkout, error) { return &Checkout{Reference: "1"}, nil }, errNoResource},
+		{"no redirect url", func(r *http.Request) (*Checkout, error) {
+			return &Checkout{Reference: "1", Payment: &mollie.Payment{}}, nil
+		}, errNoRedirectURL},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got = nil
+
+			rec := httptest.NewRecorder()
+			NewHandler(client, NewMemoryStore(), c.build, &Options{OnError: onError}).
+				ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pay", nil))
+
+			assert.Equal(t, http.StatusTeapot, rec.Code)
+			require.NotNil(t, got)
+
+			if c.want != nil {
+				assert.True(t, errors.Is(got, c.want), got.Error())
+			}
+		})
+	}
+
+	t.Run("only accepts posts", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHandler(client, NewMemoryStore(), nil, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pay", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestReturnHandler(t *testing.T) {
+	client, s := setup(t)
+	store := NewMemoryStore()
+
+	ctx := context.Background()
+	require.Nil(t, store.Save(ctx, &Session{Reference: "12345", Kind: Payment, ID: "tr_WDqYK6vllg"}))
+	require.Nil(t, store.Save(ctx, &Session{Reference: "cart-1", Kind: Order, ID: "ord_pbjz8x"}))
+
+	var result *Result
+
+	h := NewReturnHandler(client, store, func(w http.ResponseWriter, r *http.Request, res *Result) {
+		result = res
+		_, _ = w.Write([]byte(res.Outcome))
+	}, nil)
+
+	cases := []struct {
+		reference string
+		status    string
+		details   string
+		outcome   Outcome
+		reason    mollie.FailureReason
+	}{
+		{"12345", "paid", "null", Succeeded, ""},
+		{"12345", "open", "null", Pending, ""},
+		{"12345", "pending", "null", Pending, ""},
+		{"12345", "canceled", "null", Canceled, ""},
+		{"12345", "expired", "null", Expired, ""},
+		{"12345", "failed", `{"failureReason": "insufficient_funds"}`, Failed, mollie.ReasonInsufficientFunds},
+		{"cart-1", "open", "null", Pending, ""},
+		{"cart-1", "failed", `{"failureReason": "card_expired"}`, Failed, mollie.ReasonCardExpired},
+	}
+
+	for _, c := range cases {
+		t.Run(c.reference+" "+c.status, func(t *testing.T) {
+			s.status, s.details = c.status, c.details
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/return?reference="+c.reference, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, string(c.outcome), rec.Body.String())
+			assert.Equal(t, c.reason, result.FailureReason)
+			assert.Equal(t, c.reference, result.Session.Reference)
+		})
+	}
+
+	assert.Equal(t, "tr_2", result.Payment.ID)
+	assert.Equal(t, "ord_pbjz8x", result.Order.ID)
+
+	for query, status := range map[string]int{
+		"":                   http.StatusBadRequest,
+		"?reference=unknown": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/return"+query, nil))
+		assert.Equal(t, status, rec.Code, query)
+	}
+}
+
+func TestOrderOutcome(t *testing.T) {
+	for status, outcome := range map[mollie.OrderStatus]Outcome{
+		mollie.Paid:       Succeeded,
+		mollie.Authorized: Succeeded,
+		mollie.Shipping:   Succeeded,
+		mollie.Completed:  Succeeded,
+		mollie.Canceled:   Canceled,
+		mollie.Expired:    Expired,
+		mollie.Created:    Pending,
+	} {
+		assert.Equal(t, outcome, OrderOutcome(&mollie.Order{Status: status}), status)
+	}
+}
@@ -0,0 +1,279 @@
+package checkout
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// DefaultReferenceParam is the query parameter added to the redirect URL
+// to find the checkout when the shopper returns.
+const DefaultReferenceParam = "reference"
+
+var (
+	errNoResource       = errors.New("the checkout has no payment or order")
+	errNoReference      = errors.New("the checkout has no reference")
+	errNoRedirectURL    = errors.New("the checkout has no redirect url")
+	errUnknownReference = errors.New("unknown checkout reference")
+)
+
+// Options configures the checkout handlers.
+type Options struct {
+	// ReferenceParam is the query parameter holding the reference.
+	ReferenceParam string
+	// OnError writes the response for errors, the default responds
+	// with a plain status text.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func (o *Options) defaults() Options {
+	opts := Options{}
+	if o != nil {
+		opts = *o
+	}
+
+	if opts.ReferenceParam == "" {
+		opts.ReferenceParam = DefaultReferenceParam
+	}
+
+	if opts.OnError == nil {
+		opts.OnError = writeError
+	}
+
+	return opts
+}
+
+// Builder describes the checkout to create for a request.
+type Builder func(r *http.Request) (*Checkout, error)
+
+// Handler creates the resource described by a builder and redirects the
+// shopper to its checkout with a 303 See Other.
+type Handler struct {
+	client *mollie.Client
+	store  Store
+	build  Builder
+	opts   Options
+	now    func() time.Time
+}
+
+// NewHandler creates a checkout handler, a nil options value uses the
+// defaults. The reference is added to the redirect URL of the checkout.
+func NewHandler(client *mollie.Client, store Store, build Builder, opts *Options) *Handler {
+	return &Handler{
+		client: client,
+		store:  store,
+		build:  build,
+		opts:   opts.defaults(),
+		now:    time.Now,
+	}
+}
+
+// ServeHTTP implements http.Handler, only POST requests are accepted.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	ck, err := h.build(r)
+	if err != nil {
+		h.opts.OnError(w, r, err)
+
+		return
+	}
+
+	location, err := h.create(r, ck)
+	if err != nil {
+		h.opts.OnError(w, r, err)
+
+		return
+	}
+
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
+// create creates the resource of a checkout, saves its session and
+// returns the URL the shopper is sent to.
+func (h *Handler) create(r *http.Request, ck *Checkout) (string, error) {
+	if ck.Reference == "" {
+		return "", fmt.Errorf("checkout_error: %w", errNoReference)
+	}
+
+	ctx := r.Context()
+	s := &Session{Reference: ck.Reference, CreatedAt: h.now()}
+
+	var (
+		checkout *mollie.URL
+		redirect string
+	)
+
+	switch {
+	case ck.Payment != nil:
+		p := *ck.Payment
+
+		u, err := h.returnURL(p.RedirectURL, ck.Reference)
+		if err != nil {
+			return "", err
+		}
+
+		p.RedirectURL = u
+
+		_, np, err := h.client.Payments.Create(ctx, p, ck.PaymentOptions)
+		if err != nil {
+			return "", fmt.Errorf("checkout_error: %w", err)
+		}
+
+		s.Kind, s.ID = Payment, np.ID
+		checkout, redirect = np.Links.Checkout, u
+	case ck.Order != nil:
+		o := *ck.Order
+
+		u, err := h.returnURL(o.RedirectURL, ck.Reference)
+		if err != nil {
+			return "", err
+		}
+
+		o.RedirectURL = u
+
+		_, no, err := h.client.Orders.Create(ctx, o, ck.OrderOptions)
+		if err != nil {
+			return "", fmt.Errorf("checkout_error: %w", err)
+		}
+
+		s.Kind, s.ID = Order, no.ID
+		checkout, redirect = no.Links.Checkout, u
+	default:
+		return "", fmt.Errorf("checkout_error: %w", errNoResource)
+	}
+
+	if err := h.store.Save(ctx, s); err != nil {
+		return "", fmt.Errorf("checkout_error: %w", err)
+	}
+
+	// payments completed right away, like recurring ones, have no checkout.
+	if checkout == nil || checkout.Href == "" {
+		return redirect, nil
+	}
+
+	return checkout.Href, nil
+}
+
+func (h *Handler) returnURL(redirect, reference string) (string, error) {
+	if redirect == "" {
+		return "", fmt.Errorf("checkout_error: %w", errNoRedirectURL)
+	}
+
+	u, err := url.Parse(redirect)
+	if err != nil {
+		return "", fmt.Errorf("checkout_error: %w", err)
+	}
+
+	q := u.Query()
+	q.Set(h.opts.ReferenceParam, reference)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Renderer writes the page shown to the shopper for a checkout result.
+type Renderer func(w http.ResponseWriter, r *http.Request, res *Result)
+
+// ReturnHandler resolves the checkout of the redirect URL and renders
+// its outcome.
+//
+// The reference is read from the query string, so the outcome of a
+// checkout can be seen by anyone knowing its reference. Use references
+// that can't be guessed when that matters.
+type ReturnHandler struct {
+	client *mollie.Client
+	store  Store
+	render Renderer
+	opts   Options
+}
+
+// NewReturnHandler creates a return handler, a nil options value uses
+// the defaults.
+func NewReturnHandler(client *mollie.Client, store Store, render Renderer, opts *Options) *ReturnHandler {
+	return &ReturnHandler{
+		client: client,
+		store:  store,
+		render: render,
+		opts:   opts.defaults(),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *ReturnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res, err := h.Resolve(r)
+	if err != nil {
+		h.opts.OnError(w, r, err)
+
+		return
+	}
+
+	h.render(w, r, res)
+}
+
+// Resolve returns the result of the checkout referenced by a request.
+func (h *ReturnHandler) Resolve(r *http.Request) (*Result, error) {
+	ctx := r.Context()
+
+	reference := r.URL.Query().Get(h.opts.ReferenceParam)
+	if reference == "" {
+		return nil, fmt.Errorf("checkout_error: %w", errNoReference)
+	}
+
+	s, err := h.store.Get(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("checkout_error: %w", err)
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("checkout_error: %s: %w", reference, errUnknownReference)
+	}
+
+	res := &Result{Session: s}
+
+	switch s.Kind {
+	case Order:
+		_, o, err := h.client.Orders.Get(ctx, s.ID, &mollie.OrderOptions{Embed: mollie.OrderEmbeds{mollie.EmbedPayments}})
+		if err != nil {
+			return nil, fmt.Errorf("checkout_error: %w", err)
+		}
+
+		res.Order, res.Payment, res.Outcome = o, lastPayment(o), OrderOutcome(o)
+	default:
+		_, p, err := h.client.Payments.Get(ctx, s.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("checkout_error: %w", err)
+		}
+
+		res.Payment, res.Outcome = p, PaymentOutcome(p)
+	}
+
+	if res.Outcome == Failed {
+		res.FailureReason = FailureReason(res.Payment)
+	}
+
+	return res, nil
+}
+
+// writeError is the default error handler.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, errUnknownReference):
+		status = http.StatusNotFound
+	case errors.Is(err, errNoReference):
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,49 @@
+package checkout
+
+import (
+	"context"
+	"sync"
+)
+
+// Store persists the checkout sessions until the shopper returns.
+type Store interface {
+	// Get returns the session of a reference, or nil when it doesn't exist.
+	Get(ctx context.Context, reference string) (*Session, error)
+	// Save creates or replaces the session of a reference.
+	Save(ctx context.Context, s *Session) error
+}
+
+// MemoryStore is a Store keeping the sessions in memory, the shopper has
+// to return to the instance that started the checkout.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+// NewMemoryStore creates an empty in memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: map[string]Session{}}
+}
+
+// Get implements Store.
+func (ms *MemoryStore) Get(ctx context.Context, reference string) (*Session, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	s, ok := ms.sessions[reference]
+	if !ok {
+		return nil, nil
+	}
+
+	return &s, nil
+}
+
+// Save implements Store.
+func (ms *MemoryStore) Save(ctx context.Context, s *Session) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.sessions[s.Reference] = *s
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/connect"
+)
+
+// ClientLinkOwner contains the personal details of the merchant's
+// organization owner.
+type ClientLinkOwner struct {
+	Email      string `json:"email,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	Locale     Locale `json:"locale,omitempty"`
+}
+
+// ClientDetails contains the data that will be prefilled in the
+// onboarding of a new merchant created through a client link.
+//
+// Owner and Name are required, the address country is required
+// when an address is provided.
+type ClientDetails struct {
+	Owner              ClientLinkOwner `json:"owner,omitempty"`
+	Name               string          `json:"name,omitempty"`
+	Address            *Address        `json:"address,omitempty"`
+	RegistrationNumber string          `json:"registrationNumber,omitempty"`
+	VatNumber          string          `json:"vatNumber,omitempty"`
+}
+
+// ClientLinkLinks contains URL objects relevant to the client link.
+type ClientLinkLinks struct {
+	ClientLink    *URL `json:"clientLink,omitempty"`
+	Documentation *URL `json:"documentation,omitempty"`
+}
+
+// ClientLink describes a link that partners can use to onboard
+// new merchants and connect them to their OAuth application.
+type ClientLink struct {
+	ID       string          `json:"id,omitempty"`
+	Resource string          `json:"resource,omitempty"`
+	Links    ClientLinkLinks `json:"_links,omitempty"`
+}
+
+// ClientLinkAuthorizeOptions contains the query string parameters
+// appended to a client link to obtain the final client link URL.
+type ClientLinkAuthorizeOptions struct {
+	ClientID       string
+	State          string
+	Scope          []PermissionGrant
+	ApprovalPrompt connect.ApprovalPrompt
+}
+
+// ClientLinksService operates over the client links API.
+type ClientLinksService service
+
+// Create a client link based on the provided details.
+//
+// See: https://docs.mollie.com/reference/v2/client-links-api/create-client-link
+func (cls *ClientLinksService) Create(ctx context.Context, cd ClientDetails) (res *Response, cl *ClientLink, err error) {
+	res, err = cls.client.post(ctx, "v2/client-links", cd, nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &cl); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetFinalClientLink returns the URL the merchant has to be redirected to
+// in order to finish the onboarding and authorize your application.
+//
+// See: https://docs.mollie.com/reference/v2/client-links-api/create-client-link#redirecting-the-merchant-to-the-client-link
+func (cls *ClientLinksService) GetFinalClientLink(clientLink string, opts *ClientLinkAuthorizeOptions) (string, error) {
+	u, err := url.Parse(clientLink)
+	if err != nil {
+		return "", fmt.Errorf("url_parsing_error: %w", err)
+	}
+
+	if opts != nil {
+		scopes := make([]string, 0, len(opts.Scope))
+		for _, s := range opts.Scope {
+			scopes = append(scopes, string(s))
+		}
+
+		qp := u.Query()
+		qp.Set(connect.ClientIDParam, opts.ClientID)
+		qp.Set(connect.StateParam, opts.State)
+		qp.Set(connect.ScopeParam, connect.JoinScopes(scopes))
+
+		if opts.ApprovalPrompt != "" {
+			qp.Set(connect.ApprovalPromptParam, string(opts.ApprovalPrompt))
+		}
+
+		u.RawQuery = qp.Encode()
+	}
+
+	return u.String(), nil
+}
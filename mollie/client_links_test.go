@@ -0,0 +1,202 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/connect"
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/suite"
+)
+
+type clientLinksServiceSuite struct{ suite.Suite }
+
+func (cs *clientLinksServiceSuite) SetupSuite() { setEnv() }
+
+func (cs *clientLinksServiceSuite) TearDownSuite() { unsetEnv() }
+
+func (cs *clientLinksServiceSuite) TestClientLinkService_Create() {
+	type args struct {
+		ctx     context.Context
+		details ClientDetails
+	}
+
+	var details ClientDetails
+	_ = json.Unmarshal([]byte(testdata.CreateClientLinkRequest), &details)
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"create client link works as expected.",
+			args{
+				context.Background(),
+				details,
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(cs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(cs.T(), r, "POST")
+				testQuery(cs.T(), r, "testmode=true")
+
+				var got ClientDetails
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				cs.Equal(details, got)
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(testdata.CreateClientLinkResponse))
+			},
+		},
+		{
+			"create client link, an error is returned from the server",
+			args{
+				context.Background(),
+				ClientDetails{},
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			errorHandler,
+		},
+		{
+			"create client link, an error occurs when parsing json",
+			args{
+				context.Background(),
+				ClientDetails{},
+			},
+			true,
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			noPre,
+			encodingHandler,
+		},
+		{
+			"create client link, invalid url when building request",
+			args{
+				context.Background(),
+				ClientDetails{},
+			},
+			true,
+			errBadBaseURL,
+			crashSrv,
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		cs.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc("/v2/client-links", c.handler)
+
+			res, cl, err := tClient.ClientLinks.Create(c.args.ctx, c.args.details)
+			if c.wantErr {
+				cs.NotNil(err)
+				cs.EqualError(err, c.err.Error())
+			} else {
+				cs.Nil(err)
+				cs.IsType(&ClientLink{}, cl)
+				cs.Equal("csr_vZCnNQsV2UtfXxYifWKWH", cl.ID)
+				cs.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func (cs *clientLinksServiceSuite) TestClientLinkService_GetFinalClientLink() {
+	link := "https://my.mollie.com/dashboard/client-link/finalize/csr_vZCnNQsV2UtfXxYifWKWH"
+
+	cases := []struct {
+		name    string
+		link    string
+		opts    *ClientLinkAuthorizeOptions
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			"final client link contains all authorize parameters.",
+			link,
+			&ClientLinkAuthorizeOptions{
+				ClientID:       "app_j9Pakf56Ajta6Y65AkdTtAv",
+				State:          "decafbad",
+				Scope:          []PermissionGrant{OnboardingRead, OrganizationsRead},
+				ApprovalPrompt: connect.ApprovalPromptForce,
+			},
+			url.Values{
+				"client_id":       {"app_j9Pakf56Ajta6Y65AkdTtAv"},
+				"state":           {"decafbad"},
+				"scope":           {fmt.Sprintf("%s %s", OnboardingRead, OrganizationsRead)},
+				"approval_prompt": {"force"},
+			},
+			false,
+		},
+		{
+			"final client link omits approval prompt when not provided.",
+			link,
+			&ClientLinkAuthorizeOptions{
+				ClientID: "app_j9Pakf56Ajta6Y65AkdTtAv",
+				State:    "decafbad",
+				Scope:    []PermissionGrant{PaymentsRead},
+			},
+			url.Values{
+				"client_id": {"app_j9Pakf56Ajta6Y65AkdTtAv"},
+				"state":     {"decafbad"},
+				"scope":     {"payments.read"},
+			},
+			false,
+		},
+		{
+			"final client link without options returns the link untouched.",
+			link,
+			nil,
+			url.Values{},
+			false,
+		},
+		{
+			"final client link fails with a malformed link.",
+			"http://[::1]:namedport",
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	setup()
+	defer teardown()
+
+	for _, c := range cases {
+		cs.T().Run(c.name, func(t *testing.T) {
+			got, err := tClient.ClientLinks.GetFinalClientLink(c.link, c.opts)
+			if c.wantErr {
+				cs.NotNil(err)
+				return
+			}
+
+			cs.Nil(err)
+
+			u, err := url.Parse(got)
+			cs.Nil(err)
+			cs.Equal(link, fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path))
+			cs.Equal(c.want, u.Query())
+		})
+	}
+}
+
+func TestClientLinksService(t *testing.T) {
+	suite.Run(t, new(clientLinksServiceSuite))
+}
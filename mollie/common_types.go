@@ -2,17 +2,62 @@ package mollie
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
 	"strings"
 	"time"
 )
 
+var (
+	errInvalidAmount    = errors.New("amount value must be a decimal number")
+	errUnsupportedValue = errors.New("the value is not supported by the resource")
+)
+
 // Amount represents a currency and value pair.
 type Amount struct {
 	Currency string `json:"currency,omitempty"`
 	Value    string `json:"value,omitempty"`
 }
 
+// currencies without minor units, every other currency uses two decimals.
+var zeroDecimalCurrencies = map[string]bool{
+	"ISK": true,
+	"JPY": true,
+}
+
+// Rat returns the amount value as an exact rational number, a nil
+// amount is zero.
+func (a *Amount) Rat() (*big.Rat, error) {
+	if a == nil {
+		return new(big.Rat), nil
+	}
+
+	r, ok := new(big.Rat).SetString(a.Value)
+	if !ok {
+		return nil, fmt.Errorf("amount_error: %q: %w", a.Value, errInvalidAmount)
+	}
+
+	return r, nil
+}
+
+// AmountFromRat formats r using the decimals expected by Mollie for the
+// currency, rounding half away from zero.
+func AmountFromRat(currency string, r *big.Rat) *Amount {
+	// big.Rat.FloatString already rounds half away from zero.
+	return &Amount{Currency: currency, Value: r.FloatString(currencyDecimals(currency))}
+}
+
+// currencyDecimals returns the number of decimals used for currency.
+func currencyDecimals(currency string) int {
+	if zeroDecimalCurrencies[currency] {
+		return 0
+	}
+
+	return 2
+}
+
 // Address provides a human friendly representation of a geographical space.
 //
 // When providing an address object as parameter to a request, the following conditions must be met:
@@ -155,17 +200,78 @@ const (
 type EmbedValue string
 
 // Valid Embed query string value.
+//
+// Every resource supports its own values, see PaymentEmbeds,
+// OrderEmbeds, RefundEmbeds, ChargebackEmbeds, SettlementEmbeds and
+// PartnerEmbeds.
 const (
-	EmbedPayment     EmbedValue = "payment"
-	EmbedRefund      EmbedValue = "refund"
-	EmbedShipments   EmbedValue = "shipments"
-	EmbedChangebacks EmbedValue = "chanrgebacks"
+	EmbedPayment      EmbedValue = "payment"
+	EmbedPayments     EmbedValue = "payments"
+	EmbedRefund       EmbedValue = "refund"
+	EmbedRefunds      EmbedValue = "refunds"
+	EmbedShipments    EmbedValue = "shipments"
+	EmbedChargebacks  EmbedValue = "chargebacks"
+	EmbedCaptures     EmbedValue = "captures"
+	EmbedOrganization EmbedValue = "organization"
+	EmbedOnboarding   EmbedValue = "onboarding"
+
+	// Deprecated: use EmbedChargebacks.
+	EmbedChangebacks = EmbedChargebacks
 )
 
+// IncludeValue describes the valid value of include query string.
+type IncludeValue string
+
+// Valid include query string values, see PaymentIncludes and
+// MethodIncludes.
+const (
+	IncludeQRCode           IncludeValue = "details.qrCode"
+	IncludeRemainderDetails IncludeValue = "details.remainderDetails"
+	IncludeIssuers          IncludeValue = "issuers"
+	IncludePricing          IncludeValue = "pricing"
+)
+
+// encodeSet sets key to the comma separated values, skipping empty and
+// repeated ones. Values missing from supported are rejected.
+func encodeSet[T ~string](key string, v *url.Values, values []T, supported ...T) error {
+	var list []string
+
+	for _, value := range values {
+		if value == "" || contains(list, string(value)) {
+			continue
+		}
+
+		if !contains(supported, value) {
+			return fmt.Errorf("%s %q: %w", key, value, errUnsupportedValue)
+		}
+
+		list = append(list, string(value))
+	}
+
+	if len(list) > 0 {
+		v.Set(key, strings.Join(list, ","))
+	}
+
+	return nil
+}
+
+// with returns the set with the value added when missing, the set
+// itself is never modified.
+func with[S ~[]T, T comparable](set S, value T) S {
+	if contains(set, value) {
+		return set
+	}
+
+	return append(set[:len(set):len(set)], value)
+}
+
 // Rate describes service rates, further divided into fixed and percentage costs.
+//
+// Settlement costs report the variable part as Percentage.
 type Rate struct {
-	Fixed    *Amount `json:"fixed,omitempty"`
-	Variable string  `json:"variable,omitempty"`
+	Fixed      *Amount `json:"fixed,omitempty"`
+	Variable   string  `json:"variable,omitempty"`
+	Percentage string  `json:"percentage,omitempty"`
 }
 
 // Image describes a generic image resource retrieved by Mollie.
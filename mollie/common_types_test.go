@@ -1,10 +1,14 @@
 package mollie
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestShortDate_UnmarshalJSON(t *testing.T) {
@@ -49,3 +53,182 @@ func TestShortDate_MarshalJSON(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestAmount_Rat(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		value    string
+		want     string
+	}{
+		{"two decimals", "EUR", "10", "10.00"},
+		{"rounds half away from zero", "EUR", "0.005", "0.01"},
+		{"rounds negative half away from zero", "EUR", "-0.005", "-0.01"},
+		{"zero decimal currencies", "JPY", "1234.5", "1235"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := (&Amount{Value: tt.value}).Rat()
+			assert.Nil(t, err)
+			assert.Equal(t, &Amount{Currency: tt.currency, Value: tt.want}, AmountFromRat(tt.currency, r))
+		})
+	}
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		_, err := (&Amount{Value: "ten"}).Rat()
+		assert.ErrorIs(t, err, errInvalidAmount)
+	})
+}
+
+func TestEmbedsAndIncludes(t *testing.T) {
+	cases := []struct {
+		name    string
+		options interface{}
+		want    string
+	}{
+		{
+			"payment",
+			&PaymentOptions{
+				Include: PaymentIncludes{IncludeQRCode, IncludeRemainderDetails},
+				Embed:   PaymentEmbeds{EmbedRefunds, EmbedChargebacks, EmbedRefunds},
+			},
+			"v2/payments?embed=refunds%2Cchargebacks&include=details.qrCode%2Cdetails.remainderDetails",
+		},
+		{
+			"order",
+			&OrderOptions{Embed: OrderEmbeds{EmbedPayments, EmbedRefunds, EmbedShipments}},
+			"v2/payments?embed=payments%2Crefunds%2Cshipments",
+		},
+		{
+			"refund",
+			&RefundOptions{Embed: RefundEmbeds{EmbedPayment}},
+			"v2/payments?embed=payment",
+		},
+		{
+			"settlement",
+			&SettlementsListOptions{Embed: SettlementEmbeds{EmbedPayment, EmbedCaptures}},
+			"v2/payments?embed=payment%2Ccaptures",
+		},
+		{
+			"partner",
+			&GetPartnerClientOptions{Embed: PartnerEmbeds{EmbedOrganization, EmbedOnboarding}},
+			"v2/payments?embed=organization%2Conboarding",
+		},
+		{
+			"chargeback include is ignored",
+			&ChargebackOptions{Include: "details.qrCode", Embed: ChargebackEmbeds{EmbedPayment}},
+			"v2/payments?embed=payment",
+		},
+		{
+			"methods",
+			&PaymentMethodOptions{Include: MethodIncludes{IncludePricing, IncludeIssuers}},
+			"v2/payments?include=pricing%2Cissuers",
+		},
+		{
+			"empty",
+			&PaymentOptions{Embed: PaymentEmbeds{}, Include: PaymentIncludes{""}},
+			"v2/payments?",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uri, err := withQuery("v2/payments", c.options)
+			require.Nil(t, err)
+			assert.Equal(t, c.want, uri)
+		})
+	}
+
+	t.Run("unsupported values are rejected", func(t *testing.T) {
+		for _, options := range []interface{}{
+			&PaymentOptions{Embed: PaymentEmbeds{EmbedRefunds, EmbedShipments}},
+			&OrderOptions{Embed: OrderEmbeds{EmbedPayment}},
+			&RefundOptions{Embed: RefundEmbeds{EmbedRefunds}},
+			&ChargebackOptions{Embed: ChargebackEmbeds{EmbedCaptures}},
+			&GetPartnerClientOptions{Embed: PartnerEmbeds{EmbedPayments}},
+			&PaymentMethodOptions{Include: MethodIncludes{IncludeQRCode}},
+		} {
+			_, err := withQuery("v2/payments", options)
+			assert.ErrorIs(t, err, errUnsupportedValue)
+		}
+	})
+}
+
+func TestEmbeds_Rejected(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	called := false
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	_, _, err := tClient.Payments.Get(context.Background(), "tr_WDqYK6vllg", &PaymentOptions{
+		Embed: PaymentEmbeds{EmbedShipments},
+	})
+	assert.EqualError(t, err, `query_error: embed "shipments": the value is not supported by the resource`)
+	assert.False(t, called)
+}
+
+func TestEmbeds_With(t *testing.T) {
+	e := make(OrderEmbeds, 1, 4)
+	e[0] = EmbedPayments
+
+	with := e.With(EmbedRefunds)
+	assert.Equal(t, OrderEmbeds{EmbedPayments, EmbedRefunds}, with)
+	assert.Equal(t, OrderEmbeds{EmbedPayments, EmbedShipments}, e.With(EmbedShipments))
+	assert.Equal(t, OrderEmbeds{EmbedPayments, EmbedRefunds}, with, "With must not share the backing array")
+
+	assert.True(t, with.Has(EmbedRefunds))
+	assert.False(t, with.Has(EmbedCaptures))
+	assert.Equal(t, EmbedChargebacks, EmbedChangebacks)
+
+	i := MethodIncludes{IncludeIssuers}
+	assert.Equal(t, i, i.With(IncludeIssuers))
+	assert.Equal(t, MethodIncludes{IncludeIssuers, IncludePricing}, i.With(IncludePricing))
+}
+
+func TestEmbedded(t *testing.T) {
+	var p Payment
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"id": "tr_WDqYK6vllg",
+		"_embedded": {
+			"refunds": [{"resource": "refund", "id": "re_4qqhO89gsT"}],
+			"chargebacks": [{"resource": "chargeback", "id": "chb_n9z0tp"}],
+			"captures": [{"resource": "capture", "id": "cpt_4qqhO89gsT"}]
+		}
+	}`), &p))
+	require.NotNil(t, p.Embedded)
+	assert.Equal(t, "re_4qqhO89gsT", p.Embedded.Refunds[0].ID)
+	assert.Equal(t, "chb_n9z0tp", p.Embedded.Chargebacks[0].ID)
+	assert.Equal(t, "cpt_4qqhO89gsT", p.Embedded.Captures[0].ID)
+
+	b, err := json.Marshal(Payment{ID: "tr_WDqYK6vllg"})
+	require.Nil(t, err)
+	assert.NotContains(t, string(b), "_embedded")
+
+	var o Order
+	require.Nil(t, json.Unmarshal([]byte(`{"_embedded": {"shipments": [{"id": "shp_3wmsgCJN4U"}]}}`), &o))
+	require.Len(t, o.Embedded.Shipments, 1)
+	assert.Equal(t, "shp_3wmsgCJN4U", o.Embedded.Shipments[0].ID)
+
+	var c Chargeback
+	require.Nil(t, json.Unmarshal([]byte(`{"_embedded": {"payment": {"id": "tr_WDqYK6vllg", "method": "creditcard"}}}`), &c))
+	require.NotNil(t, c.Embedded.Payment)
+	assert.Equal(t, CreditCard, c.Embedded.Payment.Method)
+
+	var s Settlement
+	require.Nil(t, json.Unmarshal([]byte(`{"_embedded": {
+		"payments": [{"id": "tr_WDqYK6vllg"}],
+		"refunds": [{"id": "re_4qqhO89gsT"}],
+		"chargebacks": [{"id": "chb_n9z0tp"}],
+		"captures": [{"id": "cpt_4qqhO89gsT"}]
+	}}`), &s))
+	assert.Len(t, s.Embedded.Payments, 1)
+	assert.Len(t, s.Embedded.Refunds, 1)
+	assert.Len(t, s.Embedded.Chargebacks, 1)
+	assert.Len(t, s.Embedded.Captures, 1)
+}
@@ -2,8 +2,10 @@ package mollie
 
 // Config contains information that helps during the setup of a new Mollie client.
 type Config struct {
-	testing bool
-	auth    string
+	testing    bool
+	auth       string
+	streaming  bool
+	validation bool
 }
 
 // NewConfig builds a Mollie configuration object,
@@ -11,7 +13,30 @@ type Config struct {
 // and auth to indicate the authentication method we want to use.
 func NewConfig(t bool, auth string) *Config {
 	return &Config{
-		testing: t,
-		auth:    auth,
+		testing:    t,
+		auth:       auth,
+		validation: true,
 	}
 }
+
+// WithStreaming makes the list iterators decode the resources of each page
+// straight from the response body instead of reading the whole page into
+// memory first, it returns the config to allow chaining.
+//
+// While streaming, the response of a page stays open until all its
+// resources have been read, call Close on iterators that are not
+// consumed until the end.
+func (c *Config) WithStreaming(enabled bool) *Config {
+	c.streaming = enabled
+
+	return c
+}
+
+// WithValidation controls whether the request payloads are checked with
+// their Validate method before they are sent, validation is enabled by
+// default. It returns the config to allow chaining.
+func (c *Config) WithValidation(enabled bool) *Config {
+	c.validation = enabled
+
+	return c
+}
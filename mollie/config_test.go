@@ -23,8 +23,9 @@ func TestNewConfig(t *testing.T) {
 				auth: APITokenEnv,
 			},
 			&Config{
-				testing: true,
-				auth:    "MOLLIE_API_TOKEN",
+				testing:    true,
+				auth:       "MOLLIE_API_TOKEN",
+				validation: true,
 			},
 		},
 		{
@@ -34,8 +35,9 @@ func TestNewConfig(t *testing.T) {
 				auth: OrgTokenEnv,
 			},
 			&Config{
-				testing: true,
-				auth:    "MOLLIE_ORG_TOKEN",
+				testing:    true,
+				auth:       "MOLLIE_ORG_TOKEN",
+				validation: true,
 			},
 		},
 		{
@@ -45,8 +47,9 @@ func TestNewConfig(t *testing.T) {
 				auth: OrgTokenEnv,
 			},
 			&Config{
-				testing: true,
-				auth:    "MOLLIE_ORG_TOKEN",
+				testing:    true,
+				auth:       "MOLLIE_ORG_TOKEN",
+				validation: true,
 			},
 		},
 		{
@@ -56,8 +59,9 @@ func TestNewConfig(t *testing.T) {
 				auth: APITokenEnv,
 			},
 			&Config{
-				testing: true,
-				auth:    "MOLLIE_API_TOKEN",
+				testing:    true,
+				auth:       "MOLLIE_API_TOKEN",
+				validation: true,
 			},
 		},
 	}
@@ -68,3 +72,10 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_WithValidation(t *testing.T) {
+	c := NewConfig(true, APITokenEnv)
+	assert.True(t, c.validation)
+	assert.Same(t, c, c.WithValidation(false))
+	assert.False(t, c.validation)
+}
@@ -13,3 +13,21 @@ func Test_Endpoint(t *testing.T) {
 	require.Equal(t, ept.AuthStyle, oauth2.AuthStyleAutoDetect)
 	require.Equal(t, ept.TokenURL, tokensURL)
 }
+
+func Test_JoinScopes(t *testing.T) {
+	require.Equal(t, "", JoinScopes(nil))
+	require.Equal(t, "onboarding.read", JoinScopes([]string{"onboarding.read"}))
+	require.Equal(t, "onboarding.read onboarding.write", JoinScopes([]string{"onboarding.read", "onboarding.write"}))
+}
+
+func Test_ApprovalPromptAuthCodeOption(t *testing.T) {
+	conf := &oauth2.Config{
+		ClientID: "app_j9Pakf56Ajta6Y65AkdTtAv",
+		Endpoint: *OauthEndpoint(),
+		Scopes:   []string{"payments.read", "payments.write"},
+	}
+
+	u := conf.AuthCodeURL("decafbad", ApprovalPromptForce.AuthCodeOption())
+	require.Contains(t, u, "approval_prompt=force")
+	require.Contains(t, u, "scope=payments.read+payments.write")
+}
@@ -0,0 +1,37 @@
+package connect
+
+import (
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// ApprovalPrompt defines whether the merchant is always asked
+// to approve the requested permissions or only when needed.
+type ApprovalPrompt string
+
+// Available approval prompt values.
+const (
+	ApprovalPromptAuto  ApprovalPrompt = "auto"
+	ApprovalPromptForce ApprovalPrompt = "force"
+)
+
+// Query parameter names shared by Mollie's authorize and client link URLs.
+const (
+	ClientIDParam       = "client_id"
+	StateParam          = "state"
+	ScopeParam          = "scope"
+	ApprovalPromptParam = "approval_prompt"
+)
+
+// JoinScopes returns the scopes formatted as expected by Mollie's
+// authorize endpoint, which is a space separated list.
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// AuthCodeOption returns the approval prompt as an option accepted
+// by oauth2.Config.AuthCodeURL.
+func (ap ApprovalPrompt) AuthCodeOption() oauth2.AuthCodeOption {
+	return oauth2.SetAuthURLParam(ApprovalPromptParam, string(ap))
+}
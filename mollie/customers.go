@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -31,15 +32,38 @@ type Customer struct {
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt *time.Time             `json:"createdAt,omitempty"`
 	Links     CustomerLinks          `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // CustomersListOptions contains valid query parameters for the list customers endpoint.
 type CustomersListOptions struct {
-	From         string       `url:"from,omitempty"`
-	Limit        int          `url:"limit,omitempty"`
-	ProfileID    string       `url:"profileId,omitempty"`
+	From      string `url:"from,omitempty"`
+	Limit     int    `url:"limit,omitempty"`
+	ProfileID string `url:"profileId,omitempty"`
+	// Deprecated: SequenceType is not a list filter, use ListPaymentOptions
+	// for customer payments instead.
 	SequenceType SequenceType `url:"sequenceType,omitempty"`
-	RedirectURL  string       `url:"redirectUrl,omitempty"`
+	// Deprecated: RedirectURL is not a list filter, use ListPaymentOptions
+	// for customer payments instead.
+	RedirectURL string `url:"redirectUrl,omitempty"`
+}
+
+// CustomerOverviewOptions contains the list options used to load each of
+// the resources included in a customer overview.
+type CustomerOverviewOptions struct {
+	Mandates      *MandatesListOptions
+	Subscriptions *SubscriptionListOptions
+	Payments      *ListPaymentOptions
+}
+
+// CustomerOverview groups a customer together with its valid mandates,
+// active subscriptions and most recent payments.
+type CustomerOverview struct {
+	Customer      *Customer
+	Mandates      []*Mandate
+	Subscriptions []*Subscription
+	Payments      []*Payment
 }
 
 // CustomersList contains a embedded list of customers
@@ -49,7 +73,7 @@ type CustomersList struct {
 	Embedded struct {
 		Customers []Customer `json:"customers,omitempty"`
 	} `json:"_embedded,omitempty"`
-	Links PaginationLinks `json:"links,omitempty"`
+	Links PaginationLinks `json:"_links,omitempty"`
 }
 
 // Get finds a customer by its ID.
@@ -75,6 +99,10 @@ func (cs *CustomersService) Get(ctx context.Context, id string) (res *Response,
 //
 // See: https://docs.mollie.com/reference/v2/customers-api/create-customer
 func (cs *CustomersService) Create(ctx context.Context, c Customer) (res *Response, cc *Customer, err error) {
+	if err = cs.client.validate(&c); err != nil {
+		return
+	}
+
 	res, err = cs.client.post(ctx, "v2/customers", c, nil)
 	if err != nil {
 		return
@@ -91,6 +119,10 @@ func (cs *CustomersService) Create(ctx context.Context, c Customer) (res *Respon
 //
 // See: https://docs.mollie.com/reference/v2/customers-api/update-customer
 func (cs *CustomersService) Update(ctx context.Context, id string, c Customer) (res *Response, cc *Customer, err error) {
+	if err = cs.client.validate(&c); err != nil {
+		return
+	}
+
 	u := fmt.Sprintf("v2/customers/%s", id)
 
 	res, err = cs.client.patch(ctx, u, c, nil)
@@ -137,36 +169,165 @@ func (cs *CustomersService) List(ctx context.Context, options *CustomersListOpti
 	return
 }
 
+// Iterate returns an iterator over all the customers.
+//
+// See: https://docs.mollie.com/reference/v2/customers-api/list-customers
+func (cs *CustomersService) Iterate(options *CustomersListOptions) *Iterator[*Customer] {
+	return newListIterator[*Customer](cs.client, func(from string) (string, interface{}) {
+		opts := CustomersListOptions{}
+		if options != nil {
+			opts = *options
+		}
+
+		if from != "" {
+			opts.From = from
+		}
+
+		return "v2/customers", &opts
+	})
+}
+
 // GetPayments retrieves all payments linked to the customer.
 //
 // See: https://docs.mollie.com/reference/v2/customers-api/list-customer-payments
-func (cs *CustomersService) GetPayments(ctx context.Context, id string, options *CustomersListOptions) (res *Response, pl *PaymentList, err error) {
+func (cs *CustomersService) GetPayments(ctx context.Context, id string, options *ListPaymentOptions) (res *Response, pl *PaymentList, err error) {
+	return cs.payments(ctx, id, options)
+}
+
+// IteratePayments returns an iterator over all the payments linked
+// to the customer.
+//
+// See: https://docs.mollie.com/reference/v2/customers-api/list-customer-payments
+func (cs *CustomersService) IteratePayments(id string, options *ListPaymentOptions) *Iterator[*Payment] {
+	return newListIterator[*Payment](cs.client, func(from string) (string, interface{}) {
+		opts := ListPaymentOptions{}
+		if options != nil {
+			opts = *options
+		}
+
+		if from != "" {
+			opts.From = from
+		}
+
+		return fmt.Sprintf("v2/customers/%s/payments", id), &opts
+	})
+}
+
+// CreatePayment creates a payment for the customer.
+//
+// See: https://docs.mollie.com/reference/v2/customers-api/create-customer-payment
+func (cs *CustomersService) CreatePayment(ctx context.Context, id string, p Payment) (res *Response, pp *Payment, err error) {
+	if err = cs.client.validate(&p); err != nil {
+		return
+	}
+
 	u := fmt.Sprintf("v2/customers/%s/payments", id)
 
-	res, err = cs.list(ctx, u, options)
+	res, err = cs.client.post(ctx, u, p, nil)
 	if err != nil {
 		return
 	}
 
-	if err = json.Unmarshal(res.content, &pl); err != nil {
+	if err = json.Unmarshal(res.content, &pp); err != nil {
 		return
 	}
 
 	return
 }
 
-// CreatePayment creates a payment for the customer.
+// Overview concurrently loads a customer together with its valid mandates,
+// active subscriptions and the first page of its payments.
 //
-// See: https://docs.mollie.com/reference/v2/customers-api/create-customer-payment
-func (cs *CustomersService) CreatePayment(ctx context.Context, id string, p Payment) (res *Response, pp *Payment, err error) {
+// Mandates and subscriptions are read from all the available pages and
+// filtered by status, the size of the payments page is controlled with the
+// Limit of the payments options.
+func (cs *CustomersService) Overview(ctx context.Context, id string, opts *CustomerOverviewOptions) (co *CustomerOverview, err error) {
+	if opts == nil {
+		opts = &CustomerOverviewOptions{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+	)
+
+	co = &CustomerOverview{}
+
+	run := func(load func() error) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if lerr := load(); lerr != nil {
+				once.Do(func() {
+					err = lerr
+					cancel()
+				})
+			}
+		}()
+	}
+
+	run(func() (lerr error) {
+		_, co.Customer, lerr = cs.Get(ctx, id)
+
+		return
+	})
+
+	run(func() error {
+		it := cs.client.Mandates.Iterate(id, opts.Mandates)
+		for it.Next(ctx) {
+			if m := it.Value(); m.Status == ValidMandate {
+				co.Mandates = append(co.Mandates, m)
+			}
+		}
+
+		return it.Err()
+	})
+
+	run(func() error {
+		it := cs.client.Subscriptions.Iterate(id, opts.Subscriptions)
+		for it.Next(ctx) {
+			if s := it.Value(); s.Status == SubscriptionStatusActive {
+				co.Subscriptions = append(co.Subscriptions, s)
+			}
+		}
+
+		return it.Err()
+	})
+
+	run(func() error {
+		_, pl, lerr := cs.payments(ctx, id, opts.Payments)
+		if lerr != nil {
+			return lerr
+		}
+
+		co.Payments = pl.items()
+
+		return nil
+	})
+
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return co, nil
+}
+
+func (cs *CustomersService) payments(ctx context.Context, id string, options *ListPaymentOptions) (res *Response, pl *PaymentList, err error) {
 	u := fmt.Sprintf("v2/customers/%s/payments", id)
 
-	res, err = cs.client.post(ctx, u, p, nil)
+	res, err = cs.list(ctx, u, options)
 	if err != nil {
 		return
 	}
 
-	if err = json.Unmarshal(res.content, &pp); err != nil {
+	if err = json.Unmarshal(res.content, &pl); err != nil {
 		return
 	}
 
@@ -498,7 +498,7 @@ func (cs *customersTestSuite) TestCustomerService_GetPayments() {
 	type args struct {
 		ctx      context.Context
 		customer string
-		options  *CustomersListOptions
+		options  *ListPaymentOptions
 	}
 
 	cases := []struct {
@@ -534,7 +534,7 @@ func (cs *customersTestSuite) TestCustomerService_GetPayments() {
 			args{
 				context.Background(),
 				"cst_kEn1PlbGa",
-				&CustomersListOptions{Limit: 100},
+				&ListPaymentOptions{Limit: 100, Embed: PaymentEmbeds{EmbedRefunds}},
 			},
 			false,
 			nil,
@@ -542,6 +542,7 @@ func (cs *customersTestSuite) TestCustomerService_GetPayments() {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(cs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
 				testMethod(cs.T(), r, "GET")
+				testQuery(cs.T(), r, "embed=refunds&limit=100&testmode=true")
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
 				}
@@ -554,7 +555,7 @@ func (cs *customersTestSuite) TestCustomerService_GetPayments() {
 			args{
 				context.Background(),
 				"cst_kEn1PlbGa",
-				&CustomersListOptions{SequenceType: RecurringSequence},
+				&ListPaymentOptions{Limit: 100},
 			},
 			true,
 			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
@@ -701,6 +702,117 @@ func (cs *customersTestSuite) TestCustomerService_CreatePayment() {
 	}
 }
 
+func (cs *customersTestSuite) TestCustomerService_IteratePayments() {
+	setup()
+	defer teardown()
+
+	var queries []string
+	tMux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/payments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(cs.T(), r, "GET")
+		queries = append(queries, r.URL.Query().Encode())
+
+		if r.URL.Query().Get("from") == "" {
+			_, _ = w.Write([]byte(testdata.ListPaymentsResponse))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"count": 0, "_embedded": {"payments": []}, "_links": {"next": null}}`))
+	})
+
+	it := tClient.Customers.IteratePayments("cst_8wmqcHMN4U", &ListPaymentOptions{Limit: 5})
+	payments, err := it.All(context.Background())
+
+	cs.Nil(err)
+	cs.Len(payments, 1)
+	cs.Equal("tr_7UhSN1zuXS", payments[0].ID)
+	cs.Equal([]string{
+		"limit=5&testmode=true",
+		"from=tr_SDkzMggpvx&limit=5&testmode=true",
+	}, queries)
+}
+
+func (cs *customersTestSuite) TestCustomerService_Overview() {
+	customer := "cst_8wmqcHMN4U"
+
+	handlers := func(paymentsHandler http.HandlerFunc) {
+		tMux.HandleFunc(fmt.Sprintf("/v2/customers/%s", customer), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testdata.GetCustomerResponse))
+		})
+		tMux.HandleFunc(fmt.Sprintf("/v2/customers/%s/mandates", customer), func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from") == "" {
+				_, _ = w.Write([]byte(testdata.ListMandatesResponse))
+				return
+			}
+			_, _ = w.Write([]byte(testdata.ListMandatesLastPageResponse))
+		})
+		tMux.HandleFunc(fmt.Sprintf("/v2/customers/%s/subscriptions", customer), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testdata.ListCustomerSubscriptionsResponse))
+		})
+		tMux.HandleFunc(fmt.Sprintf("/v2/customers/%s/payments", customer), paymentsHandler)
+	}
+
+	cs.T().Run("overview loads all the customer resources.", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		handlers(func(w http.ResponseWriter, r *http.Request) {
+			testQuery(cs.T(), r, "limit=10&testmode=true")
+			_, _ = w.Write([]byte(testdata.ListPaymentsResponse))
+		})
+
+		co, err := tClient.Customers.Overview(context.Background(), customer, &CustomerOverviewOptions{
+			Payments: &ListPaymentOptions{Limit: 10},
+		})
+
+		cs.Nil(err)
+		cs.NotNil(co.Customer)
+		cs.Len(co.Mandates, 2)
+		for _, m := range co.Mandates {
+			cs.Equal(ValidMandate, m.Status)
+		}
+		cs.Len(co.Subscriptions, 1)
+		cs.Equal(SubscriptionStatusActive, co.Subscriptions[0].Status)
+		cs.Len(co.Payments, 1)
+	})
+
+	cs.T().Run("overview returns the first error found.", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		handlers(errorHandler)
+
+		co, err := tClient.Customers.Overview(context.Background(), customer, nil)
+
+		cs.Nil(co)
+		cs.EqualError(err, "500 Internal Server Error: An internal server error occurred while processing your request.")
+	})
+}
+
+func (cs *customersTestSuite) TestCustomersService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/customers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(cs.T(), r, "GET")
+
+		if r.URL.Query().Get("from") == "" {
+			testQuery(cs.T(), r, "limit=3&testmode=true")
+			_, _ = w.Write([]byte(testdata.ListCustomersResponse))
+			return
+		}
+
+		testQuery(cs.T(), r, "from=cst_stTC2WHAuS&limit=3&testmode=true")
+		_, _ = w.Write([]byte(testdata.ListCustomersLastPageResponse))
+	})
+
+	customers, err := tClient.Customers.Iterate(&CustomersListOptions{Limit: 3}).All(context.Background())
+
+	cs.Nil(err)
+	cs.Len(customers, 4)
+	cs.Equal("cst_kEn1PlbGa", customers[0].ID)
+	cs.Equal("cst_stTC2WHAuS", customers[3].ID)
+}
+
 func TestCustomersService(t *testing.T) {
 	suite.Run(t, new(customersTestSuite))
 }
@@ -0,0 +1,265 @@
+// Package dunning retries failed recurring payments on a configurable
+// schedule, escalating or suspending the cases that can't be recovered.
+//
+// The Manager is fed with the payments received in webhook calls and
+// creates the retries, using the mandate of the failed payment, when
+// RetryDue is called, usually from a periodic job.
+//
+//	m := dunning.New(client, dunning.NewMemoryStore(), &dunning.Config{
+//		Policy:  dunning.DefaultPolicy(),
+//		OnEvent: func(ctx context.Context, e dunning.Event) { log.Println(e.Type, e.Case.ID) },
+//	})
+//
+//	// webhook handler
+//	_, p, _ := client.Payments.Get(ctx, id, nil)
+//	_, err := m.Handle(ctx, p)
+//
+//	// periodic job
+//	err := m.RetryDue(ctx)
+package dunning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// CaseMetadataKey is the metadata key used to link retry payments to
+// their dunning case.
+const CaseMetadataKey = "dunningCaseId"
+
+var errNoMandate = errors.New("the payment has no customer or mandate to charge")
+
+// EventType describes what happened to a case.
+type EventType string
+
+// Emitted event types.
+const (
+	RetryScheduled EventType = "retry_scheduled"
+	RetryCreated   EventType = "retry_created"
+	RetryErrored   EventType = "retry_errored"
+	CaseRecovered  EventType = "case_recovered"
+	CaseEscalated  EventType = "case_escalated"
+	CaseSuspended  EventType = "case_suspended"
+)
+
+// Event is emitted every time a case changes, Payment is the payment
+// that caused the change when there is one and Err is only set for
+// RetryErrored events.
+type Event struct {
+	Type    EventType
+	Case    Case
+	Payment *mollie.Payment
+	Err     error
+}
+
+// Config contains the settings of a Manager.
+type Config struct {
+	Policy Policy
+	// WebhookURL receives the status updates of the retry payments,
+	// when empty the webhook of the failed payment is reused.
+	WebhookURL string
+	// OnEvent is called synchronously for every emitted event.
+	OnEvent func(ctx context.Context, e Event)
+}
+
+// Manager orchestrates the retries of failed recurring payments.
+type Manager struct {
+	client *mollie.Client
+	store  Store
+	config Config
+	now    func() time.Time
+}
+
+// New creates a Manager using the given client and store, a nil config
+// uses the DefaultPolicy.
+func New(client *mollie.Client, store Store, conf *Config) *Manager {
+	c := Config{Policy: DefaultPolicy()}
+	if conf != nil {
+		c = *conf
+	}
+
+	return &Manager{
+		client: client,
+		store:  store,
+		config: c,
+		now:    time.Now,
+	}
+}
+
+// Handle processes a payment received through a webhook.
+//
+// Failed recurring payments open a new case or move an existing one to
+// its next retry, paid retries recover their case. Payments unrelated to
+// dunning and repeated webhook calls are ignored and return a nil case.
+func (m *Manager) Handle(ctx context.Context, p *mollie.Payment) (*Case, error) {
+	if p == nil || (p.Status != mollie.PaymentFailed && p.Status != mollie.PaymentPaid) {
+		return nil, nil
+	}
+
+	id := caseID(p)
+
+	c, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("dunning_error: %w", err)
+	}
+
+	switch {
+	case c == nil && p.Status == mollie.PaymentFailed && id == p.ID && p.SequenceType == mollie.RecurringSequence:
+		if c, err = newCase(p); err != nil {
+			return nil, err
+		}
+	case c == nil, c.Status != Pending, c.LastPaymentID != p.ID:
+		return nil, nil
+	}
+
+	if p.Status == mollie.PaymentPaid {
+		c.Status = Recovered
+		c.NextRetryAt = nil
+
+		return c, m.save(ctx, c, CaseRecovered, p)
+	}
+
+	if p.Details != nil && p.Details.FailureReason != "" {
+		c.Reason = p.Details.FailureReason
+	}
+
+	return c, m.advance(ctx, c, p)
+}
+
+// RetryDue creates a recurring payment for every case whose retry date
+// has passed. Cases failing to create their payment are kept scheduled
+// so they are retried on the next run, the first error found is returned
+// once all the due cases were processed.
+func (m *Manager) RetryDue(ctx context.Context) error {
+	due, err := m.store.Due(ctx, m.now())
+	if err != nil {
+		return fmt.Errorf("dunning_error: %w", err)
+	}
+
+	var first error
+
+	for _, c := range due {
+		if err := m.retry(ctx, c); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+func (m *Manager) retry(ctx context.Context, c *Case) error {
+	webhook := m.config.WebhookURL
+	if webhook == "" {
+		webhook = c.WebhookURL
+	}
+
+	// the key is stable across runs so a retry created before the case
+	// could be saved is not charged twice.
+	key := c.ID + "-" + strconv.Itoa(c.Attempts+1)
+
+	_, p, err := m.client.Customers.CreatePayment(mollie.WithIdempotencyKey(ctx, key), c.CustomerID, mollie.Payment{
+		Amount:       c.Amount,
+		Description:  c.Description,
+		SequenceType: mollie.RecurringSequence,
+		MandateID:    c.MandateID,
+		WebhookURL:   webhook,
+		Metadata:     map[string]string{CaseMetadataKey: c.ID},
+	})
+	if err != nil {
+		m.emit(ctx, Event{Type: RetryErrored, Case: *c, Err: err})
+
+		return fmt.Errorf("dunning_error: case %s: %w", c.ID, err)
+	}
+
+	c.Attempts++
+	c.Status = Pending
+	c.LastPaymentID = p.ID
+	c.NextRetryAt = nil
+
+	return m.save(ctx, c, RetryCreated, p)
+}
+
+// advance schedules the next retry of a case or applies the final
+// action of its schedule when there are no retries left.
+func (m *Manager) advance(ctx context.Context, c *Case, p *mollie.Payment) error {
+	s := m.config.Policy.ScheduleFor(c.Reason)
+
+	if c.Attempts < len(s.Delays) {
+		next := m.now().Add(s.Delays[c.Attempts])
+		c.Status = Scheduled
+		c.NextRetryAt = &next
+
+		return m.save(ctx, c, RetryScheduled, p)
+	}
+
+	c.NextRetryAt = nil
+
+	if s.Final == Suspend {
+		if c.SubscriptionID != "" {
+			if _, _, err := m.client.Subscriptions.Delete(ctx, c.CustomerID, c.SubscriptionID); err != nil {
+				return fmt.Errorf("dunning_error: case %s: %w", c.ID, err)
+			}
+		}
+
+		c.Status = Suspended
+
+		return m.save(ctx, c, CaseSuspended, p)
+	}
+
+	c.Status = Escalated
+
+	return m.save(ctx, c, CaseEscalated, p)
+}
+
+func (m *Manager) save(ctx context.Context, c *Case, t EventType, p *mollie.Payment) error {
+	c.UpdatedAt = m.now()
+
+	if err := m.store.Save(ctx, c); err != nil {
+		return fmt.Errorf("dunning_error: %w", err)
+	}
+
+	m.emit(ctx, Event{Type: t, Case: *c, Payment: p})
+
+	return nil
+}
+
+func (m *Manager) emit(ctx context.Context, e Event) {
+	if m.config.OnEvent != nil {
+		m.config.OnEvent(ctx, e)
+	}
+}
+
+func newCase(p *mollie.Payment) (*Case, error) {
+	if p.CustomerID == "" || p.MandateID == "" {
+		return nil, fmt.Errorf("dunning_error: %s: %w", p.ID, errNoMandate)
+	}
+
+	return &Case{
+		ID:             p.ID,
+		CustomerID:     p.CustomerID,
+		MandateID:      p.MandateID,
+		SubscriptionID: p.SubscriptionID,
+		Description:    p.Description,
+		WebhookURL:     p.WebhookURL,
+		Amount:         p.Amount,
+		Reason:         mollie.ReasonUnknown,
+		Status:         Pending,
+		LastPaymentID:  p.ID,
+	}, nil
+}
+
+// caseID returns the case a payment belongs to, retries carry it in
+// their metadata and any other payment may start its own case.
+func caseID(p *mollie.Payment) string {
+	md, _ := mollie.DecodeMetadata[map[string]interface{}](p.Metadata)
+	if id, ok := md[CaseMetadataKey].(string); ok && id != "" {
+		return id
+	}
+
+	return p.ID
+}
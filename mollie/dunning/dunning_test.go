@@ -0,0 +1,282 @@
+package dunning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	mux     *http.ServeMux
+	manager *Manager
+	store   *MemoryStore
+	events  []Event
+	now     time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		store: NewMemoryStore(),
+		now:   time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	client, mux := mollietest.New(t)
+	f.mux = mux
+
+	f.manager = New(client, f.store, &Config{
+		Policy:     DefaultPolicy(),
+		WebhookURL: "https://example.org/webhooks/dunning",
+		OnEvent: func(ctx context.Context, e Event) {
+			f.events = append(f.events, e)
+		},
+	})
+	f.manager.now = func() time.Time { return f.now }
+
+	return f
+}
+
+func (f *fixture) eventTypes() []EventType {
+	types := make([]EventType, 0, len(f.events))
+	for _, e := range f.events {
+		types = append(types, e.Type)
+	}
+
+	return types
+}
+
+func failedPayment(id string, reason mollie.FailureReason) *mollie.Payment {
+	return &mollie.Payment{
+		ID:             id,
+		Status:         mollie.PaymentFailed,
+		SequenceType:   mollie.RecurringSequence,
+		CustomerID:     "cst_8wmqcHMN4U",
+		MandateID:      "mdt_h3gAaD5zP",
+		SubscriptionID: "sub_rVKGtNd6s3",
+		Description:    "Monthly plan",
+		Amount:         &mollie.Amount{Currency: "EUR", Value: "25.00"},
+		Details:        &mollie.PaymentDetails{FailureReason: reason},
+	}
+}
+
+func TestManager_RetryUntilRecovered(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	var created []map[string]interface{}
+
+	f.mux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/payments", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		var body map[string]interface{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		created = append(created, body)
+
+		_, _ = w.Write([]byte(`{"resource": "payment", "id": "tr_retry1", "status": "pending"}`))
+	})
+
+	c, err := f.manager.Handle(ctx, failedPayment("tr_first", mollie.ReasonInsufficientFunds))
+	require.Nil(t, err)
+	assert.Equal(t, Scheduled, c.Status)
+	assert.Equal(t, f.now.Add(3*day), *c.NextRetryAt)
+
+	// repeated webhook calls for the same failure are ignored.
+	c, err = f.manager.Handle(ctx, failedPayment("tr_first", mollie.ReasonInsufficientFunds))
+	require.Nil(t, err)
+	assert.Nil(t, c)
+
+	require.Nil(t, f.manager.RetryDue(ctx))
+	assert.Empty(t, created)
+
+	f.now = f.now.Add(3 * day)
+	require.Nil(t, f.manager.RetryDue(ctx))
+	require.Len(t, created, 1)
+	assert.Equal(t, "recurring", created[0]["sequenceType"])
+	assert.Equal(t, "mdt_h3gAaD5zP", created[0]["mandateId"])
+	assert.Equal(t, "https://example.org/webhooks/dunning", created[0]["webhookUrl"])
+	assert.Equal(t, map[string]interface{}{CaseMetadataKey: "tr_first"}, created[0]["metadata"])
+	assert.Equal(t, map[string]interface{}{"currency": "EUR", "value": "25.00"}, created[0]["amount"])
+
+	c, _ = f.store.Get(ctx, "tr_first")
+	assert.Equal(t, Pending, c.Status)
+	assert.Equal(t, "tr_retry1", c.LastPaymentID)
+	assert.Equal(t, 1, c.Attempts)
+
+	retry := failedPayment("tr_retry1", mollie.ReasonInsufficientFunds)
+	retry.Metadata = map[string]interface{}{CaseMetadataKey: "tr_first"}
+
+	c, err = f.manager.Handle(ctx, retry)
+	require.Nil(t, err)
+	assert.Equal(t, Scheduled, c.Status)
+	assert.Equal(t, f.now.Add(5*day), *c.NextRetryAt)
+
+	f.now = f.now.Add(5 * day)
+	require.Nil(t, f.manager.RetryDue(ctx))
+
+	retry.Status = mollie.PaymentPaid
+	c, err = f.manager.Handle(ctx, retry)
+	require.Nil(t, err)
+	assert.Equal(t, Recovered, c.Status)
+	assert.Nil(t, c.NextRetryAt)
+
+	assert.Equal(t, []EventType{
+		RetryScheduled,
+		RetryCreated,
+		RetryScheduled,
+		RetryCreated,
+		CaseRecovered,
+	}, f.eventTypes())
+}
+
+func TestManager_FinalActions(t *testing.T) {
+	t.Run("expired cards are escalated right away", func(t *testing.T) {
+		f := newFixture(t)
+
+		c, err := f.manager.Handle(context.Background(), failedPayment("tr_first", mollie.ReasonCardExpired))
+		require.Nil(t, err)
+		assert.Equal(t, Escalated, c.Status)
+		assert.Equal(t, mollie.ReasonCardExpired, c.Reason)
+		assert.Equal(t, []EventType{CaseEscalated}, f.eventTypes())
+	})
+
+	t.Run("suspending cancels the subscription", func(t *testing.T) {
+		f := newFixture(t)
+
+		canceled := false
+		f.mux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/subscriptions/sub_rVKGtNd6s3", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodDelete, r.Method)
+			canceled = true
+			_, _ = w.Write([]byte(`{"resource": "subscription", "id": "sub_rVKGtNd6s3", "status": "canceled"}`))
+		})
+
+		c, err := f.manager.Handle(context.Background(), failedPayment("tr_first", mollie.ReasonPossibleFraud))
+		require.Nil(t, err)
+		assert.True(t, canceled)
+		assert.Equal(t, Suspended, c.Status)
+		assert.Equal(t, []EventType{CaseSuspended}, f.eventTypes())
+	})
+
+	t.Run("unknown reasons use the default schedule", func(t *testing.T) {
+		f := newFixture(t)
+
+		c, err := f.manager.Handle(context.Background(), failedPayment("tr_first", ""))
+		require.Nil(t, err)
+		assert.Equal(t, mollie.ReasonUnknown, c.Reason)
+		assert.Equal(t, f.now.Add(day), *c.NextRetryAt)
+	})
+}
+
+func TestManager_Ignored(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	oneOff := failedPayment("tr_oneoff", mollie.ReasonInsufficientFunds)
+	oneOff.SequenceType = mollie.OneOffSequence
+
+	paid := failedPayment("tr_paid", "")
+	paid.Status = mollie.PaymentPaid
+
+	open := failedPayment("tr_open", "")
+	open.Status = mollie.PaymentOpen
+
+	for _, p := range []*mollie.Payment{nil, oneOff, paid, open} {
+		c, err := f.manager.Handle(ctx, p)
+		assert.Nil(t, err)
+		assert.Nil(t, c)
+	}
+
+	assert.Empty(t, f.events)
+
+	noMandate := failedPayment("tr_nomandate", mollie.ReasonInsufficientFunds)
+	noMandate.MandateID = ""
+
+	_, err := f.manager.Handle(ctx, noMandate)
+	assert.True(t, errors.Is(err, errNoMandate))
+}
+
+func TestManager_RetryErrorsKeepCasesScheduled(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	f.mux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/payments", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := f.manager.Handle(ctx, failedPayment("tr_first", mollie.ReasonInsufficientFunds))
+	require.Nil(t, err)
+
+	f.now = f.now.Add(3 * day)
+	assert.NotNil(t, f.manager.RetryDue(ctx))
+
+	c, _ := f.store.Get(ctx, "tr_first")
+	assert.Equal(t, Scheduled, c.Status)
+	assert.Equal(t, 0, c.Attempts)
+
+	due, _ := f.store.Due(ctx, f.now)
+	assert.Len(t, due, 1)
+	assert.Equal(t, []EventType{RetryScheduled, RetryErrored}, f.eventTypes())
+	assert.NotNil(t, f.events[1].Err)
+}
+
+// failingStore fails the saves while fail is set.
+type failingStore struct {
+	*MemoryStore
+	fail bool
+}
+
+func (s *failingStore) Save(ctx context.Context, c *Case) error {
+	if s.fail {
+		return errors.New("store unavailable")
+	}
+
+	return s.MemoryStore.Save(ctx, c)
+}
+
+func TestManager_RetryIdempotency(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	store := &failingStore{MemoryStore: f.store}
+	f.manager.store = store
+
+	var keys []string
+
+	f.mux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/payments", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(mollie.IdempotencyHeader))
+		_, _ = w.Write([]byte(`{"resource": "payment", "id": "tr_retry1", "status": "pending"}`))
+	})
+
+	_, err := f.manager.Handle(ctx, failedPayment("tr_first", mollie.ReasonInsufficientFunds))
+	require.Nil(t, err)
+
+	f.now = f.now.Add(3 * day)
+	store.fail = true
+	assert.NotNil(t, f.manager.RetryDue(ctx))
+
+	store.fail = false
+	require.Nil(t, f.manager.RetryDue(ctx))
+
+	assert.Equal(t, []string{"tr_first-1", "tr_first-1"}, keys)
+
+	c, _ := f.store.Get(ctx, "tr_first")
+	assert.Equal(t, Pending, c.Status)
+	assert.Equal(t, 1, c.Attempts)
+}
+
+func TestPolicy_ScheduleFor(t *testing.T) {
+	p := DefaultPolicy()
+
+	assert.Equal(t, Suspend, p.ScheduleFor(mollie.ReasonInsufficientFunds).Final)
+	assert.Len(t, p.ScheduleFor(mollie.ReasonInsufficientFunds).Delays, 3)
+	assert.Empty(t, p.ScheduleFor(mollie.ReasonCardExpired).Delays)
+	assert.Equal(t, p.Default, p.ScheduleFor(mollie.ReasonRefusedByIssuer))
+}
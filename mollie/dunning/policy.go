@@ -0,0 +1,70 @@
+package dunning
+
+import (
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Action describes what happens with a case once its retries are exhausted.
+type Action string
+
+// Available final actions.
+//
+// Mollie can't pause subscriptions through the API, suspending a case
+// cancels the subscription that created the failed payment so no new
+// charges are attempted on the unusable mandate.
+const (
+	Escalate Action = "escalate"
+	Suspend  Action = "suspend"
+)
+
+// Schedule lists the waiting time before every retry, counted from the
+// moment the previous attempt failed, and the action taken when all the
+// retries failed. A schedule without delays acts immediately.
+type Schedule struct {
+	Delays []time.Duration
+	Final  Action
+}
+
+// Policy selects the schedule used for a failed payment by its failure
+// reason, reasons without a specific schedule use Default.
+type Policy struct {
+	Schedules map[mollie.FailureReason]Schedule
+	Default   Schedule
+}
+
+const day = 24 * time.Hour
+
+// DefaultPolicy retries payments refused for a lack of funds during two
+// weeks, escalates failures that require a new mandate from the customer
+// and suspends payments flagged as fraudulent right away.
+func DefaultPolicy() Policy {
+	renew := Schedule{Final: Escalate}
+
+	return Policy{
+		Schedules: map[mollie.FailureReason]Schedule{
+			mollie.ReasonInsufficientFunds: {
+				Delays: []time.Duration{3 * day, 5 * day, 7 * day},
+				Final:  Suspend,
+			},
+			mollie.ReasonCardExpired:       renew,
+			mollie.ReasonInactiveCard:      renew,
+			mollie.ReasonInvalidCardNumber: renew,
+			mollie.ReasonPossibleFraud:     {Final: Suspend},
+		},
+		Default: Schedule{
+			Delays: []time.Duration{1 * day, 3 * day},
+			Final:  Escalate,
+		},
+	}
+}
+
+// ScheduleFor returns the schedule applying to reason.
+func (p Policy) ScheduleFor(reason mollie.FailureReason) Schedule {
+	if s, ok := p.Schedules[reason]; ok {
+		return s
+	}
+
+	return p.Default
+}
@@ -0,0 +1,109 @@
+package dunning
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Status describes the stage of a dunning case.
+type Status string
+
+// Available case statuses.
+const (
+	// Scheduled cases wait for their next retry date.
+	Scheduled Status = "scheduled"
+	// Pending cases wait for the result of a retry payment.
+	Pending   Status = "pending"
+	Recovered Status = "recovered"
+	Escalated Status = "escalated"
+	Suspended Status = "suspended"
+)
+
+// Case tracks the retries of a failed recurring payment, it is identified
+// by the ID of the payment that failed first.
+type Case struct {
+	ID             string
+	CustomerID     string
+	MandateID      string
+	SubscriptionID string
+	Description    string
+	WebhookURL     string
+	Amount         *mollie.Amount
+	Reason         mollie.FailureReason
+	Status         Status
+	Attempts       int
+	LastPaymentID  string
+	NextRetryAt    *time.Time
+	UpdatedAt      time.Time
+}
+
+// Store persists dunning cases between webhook calls and retry runs.
+type Store interface {
+	// Get returns the case with the given id, or nil when it doesn't exist.
+	Get(ctx context.Context, id string) (*Case, error)
+	// Save creates or replaces a case.
+	Save(ctx context.Context, c *Case) error
+	// Due returns the scheduled cases with a retry date before or at t.
+	Due(ctx context.Context, t time.Time) ([]*Case, error)
+}
+
+// MemoryStore is a Store keeping the cases in memory. The cases are lost
+// when the process exits, retries scheduled before a restart are never
+// charged.
+type MemoryStore struct {
+	mu    sync.Mutex
+	cases map[string]Case
+}
+
+// NewMemoryStore creates an empty in memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cases: map[string]Case{}}
+}
+
+// Get implements Store.
+func (ms *MemoryStore) Get(ctx context.Context, id string) (*Case, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	c, ok := ms.cases[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &c, nil
+}
+
+// Save implements Store.
+func (ms *MemoryStore) Save(ctx context.Context, c *Case) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.cases[c.ID] = *c
+
+	return nil
+}
+
+// Due implements Store, cases are returned by retry date.
+func (ms *MemoryStore) Due(ctx context.Context, t time.Time) ([]*Case, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var due []*Case
+
+	for _, c := range ms.cases {
+		if c.Status == Scheduled && c.NextRetryAt != nil && !c.NextRetryAt.After(t) {
+			c := c
+			due = append(due, &c)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextRetryAt.Before(*due[j].NextRetryAt)
+	})
+
+	return due, nil
+}
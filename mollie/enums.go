@@ -0,0 +1,252 @@
+package mollie
+
+// The enum types are plain strings so values added by Mollie after a
+// release of this package are decoded verbatim instead of failing, the
+// registries below only describe the values known to this version.
+
+// PaymentMethodInfo describes a payment method and the operations it
+// supports.
+type PaymentMethodInfo struct {
+	Method PaymentMethod
+	// Name is the display name used by Mollie.
+	Name string
+	// Recurring is true when the method can be used for the first payment
+	// of a recurring sequence or charged with a mandate.
+	Recurring bool
+	Refunds   bool
+	// Captures is true when the method can be authorized and captured
+	// later.
+	Captures bool
+}
+
+var paymentMethods = []PaymentMethodInfo{
+	{Method: ApplePay, Name: "Apple Pay", Recurring: true, Refunds: true},
+	{Method: Bancontact, Name: "Bancontact", Recurring: true, Refunds: true},
+	{Method: BankTransfer, Name: "Bank transfer", Refunds: true},
+	{Method: Belfius, Name: "Belfius Pay Button", Recurring: true, Refunds: true},
+	{Method: Billie, Name: "Billie", Refunds: true, Captures: true},
+	{Method: Blik, Name: "BLIK", Refunds: true},
+	{Method: CreditCard, Name: "Card", Recurring: true, Refunds: true, Captures: true},
+	{Method: DirectDebit, Name: "SEPA Direct Debit", Recurring: true, Refunds: true},
+	{Method: EPS, Name: "eps", Recurring: true, Refunds: true},
+	{Method: GiftCard, Name: "Gift cards"},
+	{Method: GiroPay, Name: "Giropay", Recurring: true, Refunds: true},
+	{Method: IDeal, Name: "iDEAL", Recurring: true, Refunds: true},
+	{Method: In3, Name: "in3", Refunds: true},
+	{Method: KBC, Name: "KBC/CBC Payment Button", Recurring: true, Refunds: true},
+	{Method: KlarnaPayLater, Name: "Pay later.", Refunds: true, Captures: true},
+	{Method: KlarnaPayNow, Name: "Pay now.", Refunds: true, Captures: true},
+	{Method: KlarnaLiceit, Name: "Slice it.", Refunds: true, Captures: true},
+	{Method: MyBank, Name: "MyBank", Recurring: true, Refunds: true},
+	{Method: PayPal, Name: "PayPal", Recurring: true, Refunds: true},
+	{Method: PaySafeCard, Name: "paysafecard"},
+	{Method: PointOfSale, Name: "Point of sale", Refunds: true},
+	{Method: PRZelewy24, Name: "Przelewy24", Refunds: true},
+	{Method: Riverty, Name: "Riverty", Refunds: true, Captures: true},
+	{Method: Sofort, Name: "SOFORT Banking", Recurring: true, Refunds: true},
+	{Method: Twint, Name: "TWINT", Refunds: true},
+	{Method: Voucher, Name: "Vouchers"},
+}
+
+var paymentMethodsByID = func() map[PaymentMethod]PaymentMethodInfo {
+	m := make(map[PaymentMethod]PaymentMethodInfo, len(paymentMethods))
+	for _, info := range paymentMethods {
+		m[info.Method] = info
+	}
+
+	return m
+}()
+
+// AllPaymentMethods returns the payment methods known to this version of
+// the package.
+func AllPaymentMethods() []PaymentMethod {
+	methods := make([]PaymentMethod, len(paymentMethods))
+	for i, info := range paymentMethods {
+		methods[i] = info.Method
+	}
+
+	return methods
+}
+
+// IsKnown reports whether the payment method is known to this version of
+// the package.
+func (m PaymentMethod) IsKnown() bool {
+	_, ok := paymentMethodsByID[m]
+
+	return ok
+}
+
+// Info returns the description of a known payment method, ok is false
+// for unknown methods.
+func (m PaymentMethod) Info() (info PaymentMethodInfo, ok bool) {
+	info, ok = paymentMethodsByID[m]
+
+	return
+}
+
+// DisplayName returns the name Mollie shows for the payment method, or
+// the method itself when it is unknown.
+func (m PaymentMethod) DisplayName() string {
+	if info, ok := paymentMethodsByID[m]; ok {
+		return info.Name
+	}
+
+	return string(m)
+}
+
+// Empty is not included, it stands for a missing label.
+var cardLabels = []CardLabel{
+	AmericaExpress,
+	CartaSi,
+	CarteBleue,
+	Dankort,
+	DinersClub,
+	Discover,
+	JCB,
+	Laser,
+	Maestro,
+	Mastercard,
+	Unionpay,
+	Visa,
+}
+
+// AllCardLabels returns the card labels known to this version of the
+// package.
+func AllCardLabels() []CardLabel {
+	return append([]CardLabel(nil), cardLabels...)
+}
+
+// IsKnown reports whether the card label is known to this version of the
+// package.
+func (l CardLabel) IsKnown() bool {
+	return contains(cardLabels, l)
+}
+
+var giftCardIssuers = []GiftCardIssuer{
+	BloemenCadeuKaart,
+	BloemPlantGiftCard,
+	Boekenbon,
+	DecaudeuKaart,
+	DelokaleDecauKaart,
+	Dinercadeau,
+	Doenkadotickets,
+	Fashioncheque,
+	Festivalcadeau,
+	Good4fun,
+	HuistuinCadeauKaart,
+	JewelCard,
+	KlusCadeu,
+	Kunstencultuurcadeaukaart,
+	Nationalebioscoopbon,
+	Nationaleentertainmentcard,
+	Nationalegolfbon,
+	Ohmygood,
+	Podiumcadeaukaart,
+	Reiscadeau,
+	Restaurantcadeau,
+	SodexoSportCulturePass,
+	Sportenfitcadeau,
+	Sustainablefashion,
+	Travelcheq,
+	Vvvgiftcard,
+	Vvvdinercheque,
+	Vvvlekkerweg,
+	Webshopgiftcard,
+	Wijncadeukaart,
+	Yourgift,
+}
+
+// AllGiftCardIssuers returns the gift card issuers known to this version
+// of the package.
+func AllGiftCardIssuers() []GiftCardIssuer {
+	return append([]GiftCardIssuer(nil), giftCardIssuers...)
+}
+
+// IsKnown reports whether the gift card issuer is known to this version
+// of the package.
+func (i GiftCardIssuer) IsKnown() bool {
+	return contains(giftCardIssuers, i)
+}
+
+var failureReasons = []FailureReason{
+	ReasonAuthenticationAbandoned,
+	ReasonAuthenticationUnavailableACS,
+	ReasonInvalidCardNumber,
+	ReasonInvalidCCV,
+	ReasonInvalidCardHolderName,
+	ReasonCardExpired,
+	ReasonInvalidCardType,
+	ReasonRefusedByIssuer,
+	ReasonInsufficientFunds,
+	ReasonInactiveCard,
+	ReasonUnknown,
+	ReasonPossibleFraud,
+	ReasonAuthenticationFailed,
+	ReasonAuthenticationRequired,
+	ReasonCardDeclined,
+	ReasonTemporaryFailure,
+}
+
+// AllFailureReasons returns the failure reasons known to this version of
+// the package.
+func AllFailureReasons() []FailureReason {
+	return append([]FailureReason(nil), failureReasons...)
+}
+
+// IsKnown reports whether the failure reason is known to this version of
+// the package.
+func (r FailureReason) IsKnown() bool {
+	return contains(failureReasons, r)
+}
+
+var permissionGrants = []PermissionGrant{
+	PaymentsRead,
+	PaymentsWrite,
+	RefundsRead,
+	RefundsWrite,
+	CustomersRead,
+	CustomersWrite,
+	MandatesRead,
+	MandatesWrite,
+	SubscriptionsRead,
+	SubscriptionsWrite,
+	ProfilesRead,
+	ProfilesWrite,
+	InvoicesRead,
+	OrdersRead,
+	OrdersWrite,
+	ShipmentsRead,
+	ShipmentsWrite,
+	OrganizationsRead,
+	OrganizationsWrite,
+	OnboardingRead,
+	OnboardingWrite,
+	BalancesRead,
+	SettlementsRead,
+	PaymentLinksRead,
+	PaymentLinksWrite,
+	TerminalsRead,
+	TerminalsWrite,
+}
+
+// AllPermissionGrants returns the permissions known to this version of
+// the package.
+func AllPermissionGrants() []PermissionGrant {
+	return append([]PermissionGrant(nil), permissionGrants...)
+}
+
+// IsKnown reports whether the permission is known to this version of the
+// package.
+func (p PermissionGrant) IsKnown() bool {
+	return contains(permissionGrants, p)
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
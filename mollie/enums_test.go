@@ -0,0 +1,86 @@
+package mollie
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentMethod_Info(t *testing.T) {
+	methods := AllPaymentMethods()
+	assert.Len(t, methods, len(paymentMethodsByID), "duplicate payment methods")
+
+	for _, m := range methods {
+		assert.True(t, m.IsKnown(), m)
+	}
+
+	info, ok := CreditCard.Info()
+	require.True(t, ok)
+	assert.Equal(t, PaymentMethodInfo{Method: CreditCard, Name: "Card", Recurring: true, Refunds: true, Captures: true}, info)
+
+	info, ok = PaySafeCard.Info()
+	require.True(t, ok)
+	assert.False(t, info.Refunds)
+	assert.False(t, info.Recurring)
+
+	assert.Equal(t, "iDEAL", IDeal.DisplayName())
+
+	unknown := PaymentMethod("satispay")
+	assert.False(t, unknown.IsKnown())
+	_, ok = unknown.Info()
+	assert.False(t, ok)
+	assert.Equal(t, "satispay", unknown.DisplayName())
+
+	methods[0] = "changed"
+	assert.Equal(t, ApplePay, AllPaymentMethods()[0])
+}
+
+func TestEnums_IsKnown(t *testing.T) {
+	for _, l := range AllCardLabels() {
+		assert.True(t, l.IsKnown(), l)
+	}
+
+	for _, i := range AllGiftCardIssuers() {
+		assert.True(t, i.IsKnown(), i)
+	}
+
+	for _, r := range AllFailureReasons() {
+		assert.True(t, r.IsKnown(), r)
+	}
+
+	for _, p := range AllPermissionGrants() {
+		assert.True(t, p.IsKnown(), p)
+	}
+
+	assert.False(t, Empty.IsKnown())
+	assert.False(t, CardLabel("Elo").IsKnown())
+	assert.False(t, GiftCardIssuer("cadeaukaart").IsKnown())
+	assert.False(t, FailureReason("do_not_honor").IsKnown())
+	assert.False(t, PermissionGrant("onbording.read").IsKnown())
+	assert.Equal(t, PermissionGrant("onboarding.read"), OnboardingRead)
+}
+
+func TestEnums_UnmarshalUnknown(t *testing.T) {
+	var p Payment
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"resource": "payment",
+		"id": "tr_WDqYK6vllg",
+		"method": "satispay",
+		"details": {"failureReason": "do_not_honor", "remainderMethod": "bizum"}
+	}`), &p))
+	assert.Equal(t, PaymentMethod("satispay"), p.Method)
+	assert.False(t, p.Method.IsKnown())
+	assert.Equal(t, FailureReason("do_not_honor"), p.Details.FailureReason)
+	assert.Equal(t, PaymentMethod("bizum"), p.Details.RemainderMethod)
+
+	var m Mandate
+	require.Nil(t, json.Unmarshal([]byte(`{"method": "creditcard", "details": {"cardLabel": "Elo"}}`), &m))
+	assert.Equal(t, CardLabel("Elo"), m.Details.CardLabel)
+
+	var pm Permission
+	require.Nil(t, json.Unmarshal([]byte(`{"id": "apikeys.read", "granted": true}`), &pm))
+	assert.Equal(t, PermissionGrant("apikeys.read"), pm.ID)
+	assert.False(t, pm.ID.IsKnown())
+}
@@ -0,0 +1,248 @@
+package export
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+)
+
+var errNoAccount = errors.New("the statement needs an IBAN or an account")
+
+// CAMT053Namespace is the ISO 20022 bank to customer statement version
+// written by the CAMT053 exporter.
+const CAMT053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// ISO 20022 codes used in the statements.
+const (
+	camtOpening = "OPBD"
+	camtClosing = "CLBD"
+	camtCredit  = "CRDT"
+	camtDebit   = "DBIT"
+	camtBooked  = "BOOK"
+	camtFee     = "fee"
+)
+
+// CAMT053 writes a settlement as an ISO 20022 CAMT.053 bank statement.
+//
+// The statement describes the Mollie balance during the settlement: it
+// opens at zero, every transaction and fee is booked as an entry and it
+// closes at the sum of all entries, the amount paid out.
+type CAMT053 struct {
+	// IBAN identifies the statement account, Account is used when empty.
+	// One of them is required.
+	IBAN    string
+	Account string
+	// MessageID defaults to the settlement ID.
+	MessageID string
+	// CreatedAt defaults to the current time.
+	CreatedAt time.Time
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	Date string `xml:"Dt"`
+}
+
+type camtBalance struct {
+	XMLName   xml.Name   `xml:"Bal"`
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	XMLName     xml.Name   `xml:"Ntry"`
+	Amount      camtAmount `xml:"Amt"`
+	Indicator   string     `xml:"CdtDbtInd"`
+	Status      string     `xml:"Sts"`
+	BookingDate camtDate   `xml:"BookgDt"`
+	ValueDate   camtDate   `xml:"ValDt"`
+	Reference   string     `xml:"AcctSvcrRef"`
+	Code        string     `xml:"BkTxCd>Prtry>Cd"`
+	EndToEndID  string     `xml:"NtryDtls>TxDtls>Refs>EndToEndId,omitempty"`
+	Remittance  string     `xml:"NtryDtls>TxDtls>RmtInf>Ustrd,omitempty"`
+}
+
+type camtAccount struct {
+	XMLName  xml.Name `xml:"Acct"`
+	IBAN     string   `xml:"Id>IBAN,omitempty"`
+	Other    string   `xml:"Id>Othr>Id,omitempty"`
+	Currency string   `xml:"Ccy"`
+}
+
+type camtGroupHeader struct {
+	XMLName   xml.Name `xml:"GrpHdr"`
+	MessageID string   `xml:"MsgId"`
+	CreatedAt string   `xml:"CreDtTm"`
+}
+
+// Export implements Exporter.
+func (c *CAMT053) Export(w io.Writer, st *reconcile.Statement) error {
+	s := st.Settlement
+	if s.Amount == nil {
+		return fmt.Errorf("export_error: settlement %s has no amount", s.ID)
+	}
+
+	if c.IBAN == "" && c.Account == "" {
+		return fmt.Errorf("export_error: %w", errNoAccount)
+	}
+
+	currency := s.Amount.Currency
+
+	created := c.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	valueDate := created
+	if s.SettledAt != nil {
+		valueDate = *s.SettledAt
+	} else if s.CreatedAt != nil {
+		valueDate = *s.CreatedAt
+	}
+
+	settled := camtDate{valueDate.Format("2006-01-02")}
+	entries, closing := c.entries(st, currency, settled)
+
+	msgID := c.MessageID
+	if msgID == "" {
+		msgID = s.ID
+	}
+
+	account := camtAccount{IBAN: c.IBAN, Currency: currency}
+	if c.IBAN == "" {
+		account.Other = c.Account
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("export_error: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	document := xml.StartElement{
+		Name: xml.Name{Local: "Document"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: CAMT053Namespace}},
+	}
+	report := xml.StartElement{Name: xml.Name{Local: "BkToCstmrStmt"}}
+	stmt := xml.StartElement{Name: xml.Name{Local: "Stmt"}}
+
+	open := camtBalance{Code: camtOpening, Indicator: camtCredit, Date: entries.openingDate(settled)}
+	open.Amount = camtAmount{currency, mollie.AmountFromRat(currency, new(big.Rat)).Value}
+
+	end := camtBalance{Code: camtClosing, Indicator: camtCredit, Date: settled}
+	if closing.Sign() < 0 {
+		end.Indicator = camtDebit
+	}
+	end.Amount = camtAmount{currency, mollie.AmountFromRat(currency, closing.Abs(closing)).Value}
+
+	steps := []func() error{
+		func() error { return enc.EncodeToken(document) },
+		func() error { return enc.EncodeToken(report) },
+		func() error {
+			return enc.Encode(camtGroupHeader{MessageID: msgID, CreatedAt: created.Format(time.RFC3339)})
+		},
+		func() error { return enc.EncodeToken(stmt) },
+		func() error { return enc.EncodeElement(s.ID, xml.StartElement{Name: xml.Name{Local: "Id"}}) },
+		func() error {
+			return enc.EncodeElement(created.Format(time.RFC3339), xml.StartElement{Name: xml.Name{Local: "CreDtTm"}})
+		},
+		func() error { return enc.Encode(account) },
+		func() error { return enc.Encode(open) },
+		func() error { return enc.Encode(end) },
+	}
+
+	for _, e := range entries {
+		e := e
+		steps = append(steps, func() error { return enc.Encode(e) })
+	}
+
+	steps = append(steps,
+		func() error { return enc.EncodeToken(stmt.End()) },
+		func() error { return enc.EncodeToken(report.End()) },
+		func() error { return enc.EncodeToken(document.End()) },
+		enc.Flush,
+	)
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return fmt.Errorf("export_error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type camtEntries []camtEntry
+
+// openingDate is the booking date of the first entry.
+func (es camtEntries) openingDate(fallback camtDate) camtDate {
+	if len(es) == 0 {
+		return fallback
+	}
+
+	return es[0].BookingDate
+}
+
+// entries returns the statement entries and the closing balance.
+func (c *CAMT053) entries(st *reconcile.Statement, currency string, valueDate camtDate) (camtEntries, *big.Rat) {
+	var entries camtEntries
+
+	balance := new(big.Rat)
+
+	add := func(e camtEntry, amount *mollie.Amount) {
+		v, err := amount.Rat()
+		if err != nil {
+			return
+		}
+
+		balance.Add(balance, v)
+
+		e.Indicator = camtCredit
+		if v.Sign() < 0 {
+			e.Indicator = camtDebit
+		}
+
+		e.Amount = camtAmount{currency, mollie.AmountFromRat(currency, v.Abs(v)).Value}
+		e.Status = camtBooked
+		e.ValueDate = valueDate
+		entries = append(entries, e)
+	}
+
+	for _, t := range st.Transactions() {
+		if t.SettlementAmount == nil {
+			continue
+		}
+
+		add(camtEntry{
+			BookingDate: camtDate{t.BookedAt.Format("2006-01-02")},
+			Reference:   t.ID,
+			Code:        string(t.Type),
+			EndToEndID:  t.PaymentID,
+			Remittance:  t.Description,
+		}, t.SettlementAmount)
+	}
+
+	for _, f := range Fees(st.Settlement) {
+		add(camtEntry{
+			BookingDate: valueDate,
+			Reference:   fmt.Sprintf("%s-%s-%s", camtFee, f.Period, f.Method),
+			Code:        camtFee,
+			Remittance:  f.Description,
+		}, negate(f.Gross))
+	}
+
+	return entries, balance
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+)
+
+// Column is a CSV column, Value extracts the cell of a transaction.
+type Column struct {
+	Header string
+	Value  func(s *reconcile.Statement, t reconcile.Transaction) string
+}
+
+// Available CSV columns.
+var (
+	ColumnSettlement = Column{"settlement", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return s.Settlement.ID
+	}}
+	ColumnReference = Column{"reference", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return s.Settlement.Reference
+	}}
+	ColumnDate = Column{"date", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return t.BookedAt.Format("2006-01-02")
+	}}
+	ColumnType = Column{"type", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return string(t.Type)
+	}}
+	ColumnID = Column{"id", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return t.ID
+	}}
+	ColumnPayment = Column{"payment", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return t.PaymentID
+	}}
+	ColumnMethod = Column{"method", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return string(t.Method)
+	}}
+	ColumnDescription = Column{"description", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		return t.Description
+	}}
+	ColumnCurrency = Column{"currency", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		if t.SettlementAmount == nil {
+			return ""
+		}
+
+		return t.SettlementAmount.Currency
+	}}
+	ColumnAmount = Column{"amount", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		if t.Amount == nil {
+			return ""
+		}
+
+		return t.Amount.Value
+	}}
+	ColumnSettlementAmount = Column{"settlement_amount", func(s *reconcile.Statement, t reconcile.Transaction) string {
+		if t.SettlementAmount == nil {
+			return ""
+		}
+
+		return t.SettlementAmount.Value
+	}}
+)
+
+// DefaultColumns are used when a CSV exporter has no columns.
+var DefaultColumns = []Column{
+	ColumnDate,
+	ColumnType,
+	ColumnID,
+	ColumnPayment,
+	ColumnMethod,
+	ColumnDescription,
+	ColumnCurrency,
+	ColumnSettlementAmount,
+}
+
+// CSV writes a row for every transaction of a settlement.
+type CSV struct {
+	Columns []Column
+	// Comma is the field delimiter, defaults to a comma.
+	Comma rune
+	// NoHeader omits the row with the column headers.
+	NoHeader bool
+}
+
+// Export implements Exporter.
+func (c *CSV) Export(w io.Writer, st *reconcile.Statement) error {
+	columns := c.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+
+	cw := csv.NewWriter(w)
+	if c.Comma != 0 {
+		cw.Comma = c.Comma
+	}
+
+	row := make([]string, len(columns))
+
+	if !c.NoHeader {
+		for i, col := range columns {
+			row[i] = col.Header
+		}
+
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("export_error: %w", err)
+		}
+	}
+
+	for _, t := range st.Transactions() {
+		for i, col := range columns {
+			row[i] = col.Value(st, t)
+		}
+
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("export_error: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("export_error: %w", err)
+	}
+
+	return nil
+}
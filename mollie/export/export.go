@@ -0,0 +1,156 @@
+// Package export writes settlements in the formats used by accounting
+// software: transaction CSV files, ISO 20022 CAMT.053 bank statements and
+// double-entry journal lines.
+//
+// Exporters work on a reconcile.Statement, so the settlement and all its
+// transactions have to be loaded first, and stream their output to an
+// io.Writer.
+//
+//	st, err := reconcile.Load(ctx, client, "stl_jDk30akdN")
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = (&export.CAMT053{IBAN: "NL55INGB0000000000"}).Export(w, st)
+package export
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+)
+
+// Exporter writes a settlement statement to w.
+type Exporter interface {
+	Export(w io.Writer, st *reconcile.Statement) error
+}
+
+// Fee is the cost charged by Mollie for a method in a settlement period.
+type Fee struct {
+	Period      string
+	Method      mollie.PaymentMethod
+	Description string
+	Count       int
+	Net         *mollie.Amount
+	VAT         *mollie.Amount
+	Gross       *mollie.Amount
+}
+
+// Fees returns the costs of every period of the settlement, sorted by
+// period. Amounts are rounded to the decimals of their currency and
+// the gross amount always equals net plus VAT after rounding.
+func Fees(s *mollie.Settlement) []Fee {
+	var fees []Fee
+
+	forEachPeriod(s, func(period string, p mollie.SettlementPeriod) {
+		for _, c := range p.Costs {
+			net := rounded(c.AmountNet)
+			vat := rounded(c.AmountVAT)
+
+			if net == nil {
+				continue
+			}
+
+			if vat == nil {
+				vat = mollie.AmountFromRat(net.Currency, new(big.Rat))
+			}
+
+			fees = append(fees, Fee{
+				Period:      period,
+				Method:      c.Method,
+				Description: c.Description,
+				Count:       c.Count,
+				Net:         net,
+				VAT:         vat,
+				Gross:       sum(net, vat),
+			})
+		}
+	})
+
+	return fees
+}
+
+// forEachPeriod calls fn for every period of a settlement in
+// chronological order, periods are formatted as YYYY-MM.
+func forEachPeriod(s *mollie.Settlement, fn func(period string, p mollie.SettlementPeriod)) {
+	type key struct{ year, month int }
+
+	var keys []key
+
+	periods := map[key]mollie.SettlementPeriod{}
+
+	for y, months := range s.Periods {
+		year, err := strconv.Atoi(y)
+		if err != nil {
+			continue
+		}
+
+		for m, p := range months {
+			month, err := strconv.Atoi(m)
+			if err != nil {
+				continue
+			}
+
+			keys = append(keys, key{year, month})
+			periods[key{year, month}] = p
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+
+		return a.year < b.year || (a.year == b.year && a.month < b.month)
+	})
+
+	for _, k := range keys {
+		fn(fmt.Sprintf("%04d-%02d", k.year, k.month), periods[k])
+	}
+}
+
+// rounded returns a rounded to the decimals of its currency, nil when it
+// is missing or can't be parsed.
+func rounded(a *mollie.Amount) *mollie.Amount {
+	if a == nil {
+		return nil
+	}
+
+	v, err := a.Rat()
+	if err != nil {
+		return nil
+	}
+
+	return mollie.AmountFromRat(a.Currency, v)
+}
+
+func sum(amounts ...*mollie.Amount) *mollie.Amount {
+	total := new(big.Rat)
+	currency := ""
+
+	for _, a := range amounts {
+		if a == nil {
+			continue
+		}
+
+		currency = a.Currency
+
+		if v, err := a.Rat(); err == nil {
+			total.Add(total, v)
+		}
+	}
+
+	return mollie.AmountFromRat(currency, total)
+}
+
+func negate(a *mollie.Amount) *mollie.Amount {
+	v, err := a.Rat()
+	if err != nil {
+		return a
+	}
+
+	return mollie.AmountFromRat(a.Currency, v.Neg(v))
+}
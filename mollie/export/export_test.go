@@ -0,0 +1,223 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statement(t *testing.T) *reconcile.Statement {
+	t.Helper()
+
+	st := &reconcile.Statement{}
+	require.Nil(t, json.Unmarshal([]byte(testdata.GetSettlementsResponse), &st.Settlement))
+
+	for _, page := range []string{testdata.ListSettlementPaymentsResponse, testdata.ListSettlementPaymentsLastPageResponse} {
+		var pl mollie.PaymentList
+		require.Nil(t, json.Unmarshal([]byte(page), &pl))
+
+		for i := range pl.Embedded.Payments {
+			st.Payments = append(st.Payments, &pl.Embedded.Payments[i])
+		}
+	}
+
+	var rl mollie.RefundList
+	require.Nil(t, json.Unmarshal([]byte(testdata.ListSettlementRefundsResponse), &rl))
+	st.Refunds = rl.Embedded.Refunds
+
+	return st
+}
+
+func TestFees(t *testing.T) {
+	fees := Fees(statement(t).Settlement)
+	require.Len(t, fees, 2)
+
+	assert.Equal(t, "2018-04", fees[0].Period)
+	assert.Equal(t, mollie.IDeal, fees[0].Method)
+	assert.Equal(t, "2.10", fees[0].Net.Value)
+	assert.Equal(t, "0.44", fees[0].VAT.Value)
+	assert.Equal(t, "2.54", fees[0].Gross.Value)
+
+	assert.Equal(t, "0.50", fees[1].Net.Value)
+	assert.Equal(t, "0.11", fees[1].VAT.Value)
+	assert.Equal(t, "0.61", fees[1].Gross.Value)
+}
+
+func TestCSV_Export(t *testing.T) {
+	st := statement(t)
+
+	t.Run("default columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.Nil(t, (&CSV{}).Export(&buf, st))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 9)
+		assert.Equal(t, "date,type,id,payment,method,description,currency,settlement_amount", lines[0])
+		assert.Equal(t, "2018-04-01,payment,tr_7UhSN1zuXS,tr_7UhSN1zuXS,ideal,Order tr_7UhSN1zuXS,EUR,10.00", lines[1])
+		assert.Equal(t, "2018-04-03,refund,re_4qqhO89gsT,tr_7UhSN1zuXS,ideal,,EUR,-20.00", lines[5])
+	})
+
+	t.Run("custom columns and delimiter", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		exp := &CSV{
+			Columns:  []Column{ColumnReference, ColumnID, ColumnAmount},
+			Comma:    ';',
+			NoHeader: true,
+		}
+		require.Nil(t, exp.Export(&buf, st))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 8)
+		assert.Equal(t, "1234567.1804.03;tr_7UhSN1zuXS;10.00", lines[0])
+	})
+
+	t.Run("write errors are returned", func(t *testing.T) {
+		assert.NotNil(t, (&CSV{}).Export(failingWriter{}, st))
+	})
+}
+
+func TestCAMT053_Export(t *testing.T) {
+	var buf bytes.Buffer
+
+	exp := &CAMT053{
+		IBAN:      "NL55INGB0000000000",
+		CreatedAt: time.Date(2018, 4, 6, 12, 0, 0, 0, time.UTC),
+	}
+	require.Nil(t, exp.Export(&buf, statement(t)))
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, xml.Header))
+	assert.Contains(t, out, `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">`)
+	assert.Contains(t, out, "<MsgId>stl_jDk30akdN</MsgId>")
+	assert.Contains(t, out, "<IBAN>NL55INGB0000000000</IBAN>")
+
+	var doc struct {
+		Statement struct {
+			ID       string `xml:"Id"`
+			Balances []struct {
+				Code      string `xml:"Tp>CdOrPrtry>Cd"`
+				Amount    string `xml:"Amt"`
+				Indicator string `xml:"CdtDbtInd"`
+			} `xml:"Bal"`
+			Entries []struct {
+				Amount    string `xml:"Amt"`
+				Indicator string `xml:"CdtDbtInd"`
+				Reference string `xml:"AcctSvcrRef"`
+				Code      string `xml:"BkTxCd>Prtry>Cd"`
+			} `xml:"Ntry"`
+		} `xml:"BkToCstmrStmt>Stmt"`
+	}
+	require.Nil(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "stl_jDk30akdN", doc.Statement.ID)
+	require.Len(t, doc.Statement.Balances, 2)
+	assert.Equal(t, "OPBD", doc.Statement.Balances[0].Code)
+	assert.Equal(t, "0.00", doc.Statement.Balances[0].Amount)
+	assert.Equal(t, "CLBD", doc.Statement.Balances[1].Code)
+	assert.Equal(t, "39.75", doc.Statement.Balances[1].Amount)
+	assert.Equal(t, "CRDT", doc.Statement.Balances[1].Indicator)
+
+	require.Len(t, doc.Statement.Entries, 10)
+	assert.Equal(t, "10.00", doc.Statement.Entries[0].Amount)
+	assert.Equal(t, "CRDT", doc.Statement.Entries[0].Indicator)
+	assert.Equal(t, "re_4qqhO89gsT", doc.Statement.Entries[4].Reference)
+	assert.Equal(t, "DBIT", doc.Statement.Entries[4].Indicator)
+	assert.Equal(t, "fee", doc.Statement.Entries[8].Code)
+	assert.Equal(t, "2.54", doc.Statement.Entries[8].Amount)
+	assert.Equal(t, "DBIT", doc.Statement.Entries[8].Indicator)
+}
+
+func TestCAMT053_ExportWithoutAmount(t *testing.T) {
+	st := statement(t)
+	st.Settlement.Amount = nil
+
+	assert.NotNil(t, (&CAMT053{IBAN: "NL55INGB0000000000"}).Export(&bytes.Buffer{}, st))
+}
+
+func TestCAMT053_ExportAccount(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.Nil(t, (&CAMT053{Account: "1234567"}).Export(&buf, statement(t)))
+
+	var doc struct {
+		Account string `xml:"BkToCstmrStmt>Stmt>Acct>Id>Othr>Id"`
+	}
+	require.Nil(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "1234567", doc.Account)
+
+	buf.Reset()
+	err := (&CAMT053{}).Export(&buf, statement(t))
+	assert.ErrorIs(t, err, errNoAccount)
+	assert.Zero(t, buf.Len())
+}
+
+func TestJournal_Lines(t *testing.T) {
+	lines := (&Journal{}).Lines(statement(t))
+	require.Len(t, lines, 12)
+
+	type booking struct {
+		account, debit, credit string
+	}
+
+	value := func(a *mollie.Amount) string {
+		if a == nil {
+			return ""
+		}
+
+		return a.Value
+	}
+
+	var got []booking
+	for _, l := range lines {
+		got = append(got, booking{l.Account, value(l.Debit), value(l.Credit)})
+	}
+
+	assert.Equal(t, []booking{
+		{"mollie_balance", "86.10", ""},
+		{"revenue", "", "86.10"},
+		{"refunds", "43.20", ""},
+		{"mollie_balance", "", "43.20"},
+		{"payment_fees", "2.10", ""},
+		{"vat_receivable", "0.44", ""},
+		{"mollie_balance", "", "2.54"},
+		{"payment_fees", "0.50", ""},
+		{"vat_receivable", "0.11", ""},
+		{"mollie_balance", "", "0.61"},
+		{"bank", "39.75", ""},
+		{"mollie_balance", "", "39.75"},
+	}, got)
+
+	assert.Equal(t, "stl_jDk30akdN/2018-04/ideal", lines[0].Entry)
+	assert.Equal(t, "2018-04-06", lines[0].Date.Format("2006-01-02"))
+}
+
+func TestJournal_Export(t *testing.T) {
+	var buf bytes.Buffer
+
+	accounts := DefaultAccounts()
+	accounts.Bank = ""
+
+	require.Nil(t, (&Journal{Accounts: accounts}).Export(&buf, statement(t)))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 11)
+	assert.Equal(t, "date,entry,account,description,debit,credit", lines[0])
+	assert.Equal(t, "2018-04-06,stl_jDk30akdN/2018-04/ideal,mollie_balance,iDEAL,86.10,", lines[1])
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
@@ -0,0 +1,169 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+)
+
+// Accounts maps the movements of a settlement to ledger accounts.
+type Accounts struct {
+	// Balance is the clearing account holding the Mollie balance.
+	Balance     string
+	Revenue     string
+	Refunds     string
+	Chargebacks string
+	Fees        string
+	VAT         string
+	// Bank receives the payout, it is only booked for paid out
+	// settlements when not empty.
+	Bank string
+}
+
+// DefaultAccounts returns descriptive account names, most ledgers will
+// need them replaced by their account numbers.
+func DefaultAccounts() Accounts {
+	return Accounts{
+		Balance:     "mollie_balance",
+		Revenue:     "revenue",
+		Refunds:     "refunds",
+		Chargebacks: "chargebacks",
+		Fees:        "payment_fees",
+		VAT:         "vat_receivable",
+		Bank:        "bank",
+	}
+}
+
+// JournalLine is one side of a double-entry booking, all the lines
+// sharing an Entry balance each other.
+type JournalLine struct {
+	Date        time.Time
+	Entry       string
+	Account     string
+	Description string
+	Debit       *mollie.Amount
+	Credit      *mollie.Amount
+}
+
+// Journal writes the double-entry bookings of a settlement as CSV with
+// the date, entry, account, description, debit and credit columns.
+//
+// The bookings are built from the revenue and costs of the settlement
+// periods: gross revenue per method, refunds, chargebacks, fees split
+// in net and VAT and the payout.
+type Journal struct {
+	Accounts Accounts
+}
+
+// Lines returns the journal lines of a settlement.
+func (j *Journal) Lines(st *reconcile.Statement) []JournalLine {
+	s := st.Settlement
+
+	accounts := j.Accounts
+	if accounts == (Accounts{}) {
+		accounts = DefaultAccounts()
+	}
+
+	date := time.Time{}
+	switch {
+	case s.SettledAt != nil:
+		date = *s.SettledAt
+	case s.CreatedAt != nil:
+		date = *s.CreatedAt
+	}
+
+	var lines []JournalLine
+
+	book := func(entry, description string, debit, credit []JournalLine) {
+		for _, l := range append(debit, credit...) {
+			l.Date, l.Entry, l.Description = date, entry, description
+			lines = append(lines, l)
+		}
+	}
+
+	forEachPeriod(s, func(period string, p mollie.SettlementPeriod) {
+		for _, rv := range p.Revenue {
+			gross := rounded(rv.AmountGross)
+			if gross == nil {
+				continue
+			}
+
+			// the periods show deductions both as negative and positive values.
+			if v, _ := gross.Rat(); v.Sign() < 0 {
+				gross = negate(gross)
+			}
+
+			entry := fmt.Sprintf("%s/%s/%s", s.ID, period, rv.Method)
+
+			switch rv.Method {
+			case reconcile.RefundMethod, reconcile.ChargebackMethod:
+				account := accounts.Refunds
+				if rv.Method == reconcile.ChargebackMethod {
+					account = accounts.Chargebacks
+				}
+
+				book(entry, rv.Description,
+					[]JournalLine{{Account: account, Debit: gross}},
+					[]JournalLine{{Account: accounts.Balance, Credit: gross}},
+				)
+			default:
+				book(entry, rv.Description,
+					[]JournalLine{{Account: accounts.Balance, Debit: gross}},
+					[]JournalLine{{Account: accounts.Revenue, Credit: gross}},
+				)
+			}
+		}
+	})
+
+	for _, f := range Fees(s) {
+		book(fmt.Sprintf("%s/%s/%s/fees", s.ID, f.Period, f.Method), f.Description,
+			[]JournalLine{{Account: accounts.Fees, Debit: f.Net}, {Account: accounts.VAT, Debit: f.VAT}},
+			[]JournalLine{{Account: accounts.Balance, Credit: f.Gross}},
+		)
+	}
+
+	if accounts.Bank != "" && s.Status == mollie.SettlementStatusPaidOut && s.Amount != nil {
+		book(s.ID+"/payout", "Payout "+s.Reference,
+			[]JournalLine{{Account: accounts.Bank, Debit: s.Amount}},
+			[]JournalLine{{Account: accounts.Balance, Credit: s.Amount}},
+		)
+	}
+
+	return lines
+}
+
+// Export implements Exporter.
+func (j *Journal) Export(w io.Writer, st *reconcile.Statement) error {
+	cw := csv.NewWriter(w)
+
+	value := func(a *mollie.Amount) string {
+		if a == nil {
+			return ""
+		}
+
+		return a.Value
+	}
+
+	if err := cw.Write([]string{"date", "entry", "account", "description", "debit", "credit"}); err != nil {
+		return fmt.Errorf("export_error: %w", err)
+	}
+
+	for _, l := range j.Lines(st) {
+		row := []string{l.Date.Format("2006-01-02"), l.Entry, l.Account, l.Description, value(l.Debit), value(l.Credit)}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("export_error: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("export_error: %w", err)
+	}
+
+	return nil
+}
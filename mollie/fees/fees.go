@@ -0,0 +1,400 @@
+// Package fees estimates the transaction fees charged by Mollie using
+// the pricing of a profile.
+//
+// The pricing is loaded once from the methods API and used to compute
+// the expected fee of payments by method, card region and amount. The
+// estimates can be compared with the costs of a settlement to spot
+// pricing changes:
+//
+//	e, err := fees.Load(ctx, client, "pfl_v9hTwCvYqw")
+//	if err != nil {
+//		return err
+//	}
+//
+//	fee, err := e.EstimatePayment(payment)
+//
+//	st, err := reconcile.Load(ctx, client, "stl_jDk30akdN")
+//	drift, err := e.Reconcile(st)
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+)
+
+var (
+	errNoPricing      = errors.New("no pricing available")
+	errNoAmount       = errors.New("the amount is missing")
+	errCurrency       = errors.New("the amount and the pricing use different currencies")
+	errInvalidPricing = errors.New("invalid pricing")
+)
+
+// Estimator computes fees using the pricing of the methods of a profile.
+type Estimator struct {
+	pricing map[mollie.PaymentMethod][]*mollie.PaymentMethodPricing
+}
+
+// Load requests all the methods of a profile including their pricing
+// and returns an estimator for them. An empty profile ID uses the
+// profile of the API key.
+func Load(ctx context.Context, client *mollie.Client, profileID string) (*Estimator, error) {
+	_, ml, err := client.PaymentMethods.All(ctx, &mollie.PaymentMethodsListOptions{
+		PaymentMethodOptions: mollie.PaymentMethodOptions{
+			ProfileID: profileID,
+			Include:   mollie.MethodIncludes{mollie.IncludePricing},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fees_error: %w", err)
+	}
+
+	return New(ml.Embedded.Methods), nil
+}
+
+// New creates an estimator for the pricing of methods, they must have
+// been requested including their pricing.
+func New(methods []*mollie.PaymentMethodDetails) *Estimator {
+	e := &Estimator{pricing: map[mollie.PaymentMethod][]*mollie.PaymentMethodPricing{}}
+
+	for _, m := range methods {
+		if m == nil || len(m.Pricing) == 0 {
+			continue
+		}
+
+		method := mollie.PaymentMethod(m.ID)
+		e.pricing[method] = append(e.pricing[method], m.Pricing...)
+	}
+
+	return e
+}
+
+// Pricing returns the pricing of a method for a fee region. Methods
+// without regions use their only pricing, region is ignored for them.
+func (e *Estimator) Pricing(method mollie.PaymentMethod, region mollie.FeeRegion) (*mollie.PaymentMethodPricing, error) {
+	prices := e.pricing[method]
+
+	for _, p := range prices {
+		if p.FeeRegion == region {
+			return p, nil
+		}
+	}
+
+	if len(prices) == 1 {
+		return prices[0], nil
+	}
+
+	return nil, fmt.Errorf("fees_error: %s in region %q: %w", method, region, errNoPricing)
+}
+
+// Estimate returns the fee charged for a payment of amount with a method
+// in a fee region, rounded to the decimals of its currency.
+func (e *Estimator) Estimate(method mollie.PaymentMethod, region mollie.FeeRegion, amount *mollie.Amount) (*mollie.Amount, error) {
+	fee, currency, err := e.estimate(method, region, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return mollie.AmountFromRat(currency, fee), nil
+}
+
+// EstimatePayment returns the fee of a payment using its method, the fee
+// region of its details and its settlement amount when available.
+func (e *Estimator) EstimatePayment(p *mollie.Payment) (*mollie.Amount, error) {
+	fee, currency, err := e.estimatePayment(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return mollie.AmountFromRat(currency, fee), nil
+}
+
+// Estimate is the expected fee of a group of payments.
+type Estimate struct {
+	Method mollie.PaymentMethod
+	Count  int
+	Fee    *mollie.Amount
+}
+
+// EstimatePayments returns the expected fees of payments per method,
+// sorted by method. Fees are summed before rounding.
+func (e *Estimator) EstimatePayments(payments []*mollie.Payment) ([]Estimate, error) {
+	totals, currencies, counts, err := e.totals(payments, false)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates := make([]Estimate, 0, len(totals))
+	for method, total := range totals {
+		estimates = append(estimates, Estimate{
+			Method: method,
+			Count:  counts[method],
+			Fee:    mollie.AmountFromRat(currencies[method], total),
+		})
+	}
+
+	sort.Slice(estimates, func(i, j int) bool { return estimates[i].Method < estimates[j].Method })
+
+	return estimates, nil
+}
+
+// Drift compares the costs of a method in a settlement with the fees
+// estimated for its payments.
+type Drift struct {
+	Method mollie.PaymentMethod
+	// Count is the number of transactions charged in the settlement and
+	// EstimatedCount the number of payments the estimate is based on.
+	Count          int
+	EstimatedCount int
+	Estimated      *mollie.Amount
+	Actual         *mollie.Amount
+	// Difference is Actual minus Estimated.
+	Difference *mollie.Amount
+	// RateChanged is set when the settlement charged a rate not found in
+	// the pricing of the method.
+	RateChanged bool
+}
+
+// Drifted reports whether the costs differ from the estimate.
+func (d *Drift) Drifted() bool {
+	if d.RateChanged {
+		return true
+	}
+
+	v, err := d.Difference.Rat()
+
+	return err != nil || v.Sign() != 0
+}
+
+// Reconcile compares the net costs of a settlement, summed over all its
+// periods, with the fees estimated for its payments. Only the methods
+// with a pricing are compared, sorted by method.
+func (e *Estimator) Reconcile(st *reconcile.Statement) ([]Drift, error) {
+	totals, currencies, counts, err := e.totals(st.Payments, true)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := map[mollie.PaymentMethod]*Drift{}
+	actual := map[mollie.PaymentMethod]*big.Rat{}
+
+	drift := func(method mollie.PaymentMethod) *Drift {
+		d, ok := drifts[method]
+		if !ok {
+			d = &Drift{Method: method, EstimatedCount: counts[method]}
+			drifts[method] = d
+			actual[method] = new(big.Rat)
+		}
+
+		return d
+	}
+
+	for method := range totals {
+		drift(method)
+	}
+
+	for _, months := range st.Settlement.Periods {
+		for _, period := range months {
+			for _, c := range period.Costs {
+				if c == nil || len(e.pricing[c.Method]) == 0 || c.AmountNet == nil {
+					continue
+				}
+
+				v, err := c.AmountNet.Rat()
+				if err != nil {
+					return nil, fmt.Errorf("fees_error: %s costs: %w", c.Method, err)
+				}
+
+				d := drift(c.Method)
+				d.Count += c.Count
+				d.RateChanged = d.RateChanged || !e.charged(c.Method, c.Rate)
+				actual[c.Method].Add(actual[c.Method], v)
+
+				if _, ok := currencies[c.Method]; !ok {
+					currencies[c.Method] = c.AmountNet.Currency
+				}
+			}
+		}
+	}
+
+	res := make([]Drift, 0, len(drifts))
+
+	for method, d := range drifts {
+		estimated := totals[method]
+		if estimated == nil {
+			estimated = new(big.Rat)
+		}
+
+		currency := currencies[method]
+		d.Estimated = mollie.AmountFromRat(currency, estimated)
+		d.Actual = mollie.AmountFromRat(currency, actual[method])
+		d.Difference = mollie.AmountFromRat(currency, new(big.Rat).Sub(actual[method], estimated))
+
+		res = append(res, *d)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Method < res[j].Method })
+
+	return res, nil
+}
+
+// charged reports whether a settlement rate matches one of the prices
+// of the method.
+func (e *Estimator) charged(method mollie.PaymentMethod, rate *mollie.Rate) bool {
+	if rate == nil {
+		return true
+	}
+
+	percentage := rate.Percentage
+	if percentage == "" {
+		percentage = rate.Variable
+	}
+
+	for _, p := range e.pricing[method] {
+		if equalAmounts(p.Fixed, rate.Fixed) && equalPercentages(p.Variable, percentage) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// totals sums the fees of payments per method. When skip is set the
+// payments of methods without pricing are ignored instead of failing.
+func (e *Estimator) totals(payments []*mollie.Payment, skip bool) (
+	totals map[mollie.PaymentMethod]*big.Rat,
+	currencies map[mollie.PaymentMethod]string,
+	counts map[mollie.PaymentMethod]int,
+	err error,
+) {
+	totals = map[mollie.PaymentMethod]*big.Rat{}
+	currencies = map[mollie.PaymentMethod]string{}
+	counts = map[mollie.PaymentMethod]int{}
+
+	for _, p := range payments {
+		if p == nil {
+			continue
+		}
+
+		method := p.Method
+		if skip && len(e.pricing[method]) == 0 {
+			continue
+		}
+
+		fee, currency, err := e.estimatePayment(p)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if totals[method] == nil {
+			totals[method] = new(big.Rat)
+			currencies[method] = currency
+		}
+
+		totals[method].Add(totals[method], fee)
+		counts[method]++
+	}
+
+	return totals, currencies, counts, nil
+}
+
+func (e *Estimator) estimatePayment(p *mollie.Payment) (*big.Rat, string, error) {
+	region := mollie.FeeRegion("")
+	if p.Details != nil {
+		region = p.Details.FeeRegion
+	}
+
+	amount := p.Amount
+	if p.SettlementAmount != nil {
+		amount = p.SettlementAmount
+	}
+
+	fee, currency, err := e.estimate(p.Method, region, amount)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w (payment %s)", err, p.ID)
+	}
+
+	return fee, currency, nil
+}
+
+// estimate returns the unrounded fee and its currency.
+func (e *Estimator) estimate(method mollie.PaymentMethod, region mollie.FeeRegion, amount *mollie.Amount) (*big.Rat, string, error) {
+	if amount == nil {
+		return nil, "", fmt.Errorf("fees_error: %w", errNoAmount)
+	}
+
+	pricing, err := e.Pricing(method, region)
+	if err != nil {
+		return nil, "", err
+	}
+
+	value, err := amount.Rat()
+	if err != nil {
+		return nil, "", fmt.Errorf("fees_error: %w", err)
+	}
+
+	fee := new(big.Rat)
+	currency := amount.Currency
+
+	if pricing.Fixed != nil {
+		fixed, err := pricing.Fixed.Rat()
+		if err != nil {
+			return nil, "", fmt.Errorf("fees_error: %s fixed: %w", method, errInvalidPricing)
+		}
+
+		fee.Add(fee, fixed)
+		currency = pricing.Fixed.Currency
+	}
+
+	variable, err := percentage(pricing.Variable)
+	if err != nil {
+		return nil, "", fmt.Errorf("fees_error: %s variable %q: %w", method, pricing.Variable, errInvalidPricing)
+	}
+
+	if variable.Sign() != 0 {
+		if amount.Currency != currency {
+			return nil, "", fmt.Errorf("fees_error: %s %s: %w", amount.Currency, currency, errCurrency)
+		}
+
+		fee.Add(fee, variable.Mul(variable, value))
+	}
+
+	return fee, currency, nil
+}
+
+// percentage parses a percentage as a fraction, empty values are zero.
+func percentage(s string) (*big.Rat, error) {
+	if s == "" {
+		return new(big.Rat), nil
+	}
+
+	v, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, errInvalidPricing
+	}
+
+	return v.Quo(v, big.NewRat(100, 1)), nil
+}
+
+func equalPercentages(a, b string) bool {
+	x, errX := percentage(a)
+	y, errY := percentage(b)
+
+	return errX == nil && errY == nil && x.Cmp(y) == 0
+}
+
+func equalAmounts(a, b *mollie.Amount) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	x, errX := a.Rat()
+	y, errY := b.Rat()
+
+	return errX == nil && errY == nil && a.Currency == b.Currency && x.Cmp(y) == 0
+}
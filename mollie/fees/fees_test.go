@@ -0,0 +1,179 @@
+package fees
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/reconcile"
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pricedMethods = `{
+    "count": 2,
+    "_embedded": {"methods": [
+        {
+            "resource": "method", "id": "ideal", "description": "iDEAL",
+            "pricing": [
+                {"description": "Netherlands", "fixed": {"value": "0.29", "currency": "EUR"}, "variable": "0"}
+            ]
+        },
+        {
+            "resource": "method", "id": "creditcard", "description": "Credit card",
+            "pricing": [
+                {"description": "Commercial & non-European cards", "fixed": {"value": "0.25", "currency": "EUR"}, "variable": "2.8", "feeRegion": "other"},
+                {"description": "European cards", "fixed": {"value": "0.25", "currency": "EUR"}, "variable": "1.8", "feeRegion": "intra-eu"},
+                {"description": "American Express", "fixed": {"value": "0.25", "currency": "EUR"}, "variable": "2.8", "feeRegion": "american-express"}
+            ]
+        }
+    ]},
+    "_links": {}
+}`
+
+func estimator(t *testing.T) *Estimator {
+	t.Helper()
+
+	var ml mollie.PaymentMethodsList
+	require.Nil(t, json.Unmarshal([]byte(pricedMethods), &ml))
+
+	return New(ml.Embedded.Methods)
+}
+
+func eur(v string) *mollie.Amount {
+	return &mollie.Amount{Currency: "EUR", Value: v}
+}
+
+func TestLoad(t *testing.T) {
+	var query url.Values
+
+	client, mux := mollietest.New(t)
+	mux.HandleFunc("/v2/methods/all", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(pricedMethods))
+	})
+
+	e, err := Load(context.Background(), client, "pfl_v9hTwCvYqw")
+	require.Nil(t, err)
+	assert.Equal(t, "pricing", query.Get("include"))
+	assert.Equal(t, "pfl_v9hTwCvYqw", query.Get("profileId"))
+
+	fee, err := e.Estimate(mollie.IDeal, "", eur("10.00"))
+	require.Nil(t, err)
+	assert.Equal(t, "0.29", fee.Value)
+}
+
+func TestEstimator_Estimate(t *testing.T) {
+	e := estimator(t)
+
+	cases := []struct {
+		method mollie.PaymentMethod
+		region mollie.FeeRegion
+		amount *mollie.Amount
+		want   string
+	}{
+		{mollie.IDeal, "", eur("100.00"), "0.29"},
+		{mollie.IDeal, mollie.IntraEU, eur("100.00"), "0.29"},
+		{mollie.CreditCard, mollie.IntraEU, eur("100.00"), "2.05"},
+		{mollie.CreditCard, mollie.Other, eur("100.00"), "3.05"},
+		{mollie.CreditCard, mollie.IntraEU, eur("12.34"), "0.47"},
+	}
+
+	for _, c := range cases {
+		fee, err := e.Estimate(c.method, c.region, c.amount)
+		require.Nil(t, err)
+		assert.Equal(t, c.want, fee.Value, "%s %s %s", c.method, c.region, c.amount.Value)
+		assert.Equal(t, "EUR", fee.Currency)
+	}
+}
+
+func TestEstimator_EstimateErrors(t *testing.T) {
+	e := estimator(t)
+
+	_, err := e.Estimate(mollie.CreditCard, "", eur("10.00"))
+	assert.True(t, errors.Is(err, errNoPricing))
+
+	_, err = e.Estimate(mollie.PayPal, "", eur("10.00"))
+	assert.True(t, errors.Is(err, errNoPricing))
+
+	_, err = e.Estimate(mollie.CreditCard, mollie.Other, &mollie.Amount{Currency: "USD", Value: "10.00"})
+	assert.True(t, errors.Is(err, errCurrency))
+
+	_, err = e.Estimate(mollie.IDeal, "", nil)
+	assert.True(t, errors.Is(err, errNoAmount))
+}
+
+func TestEstimator_EstimatePayments(t *testing.T) {
+	e := estimator(t)
+
+	payments := []*mollie.Payment{
+		{ID: "tr_1", Method: mollie.IDeal, Amount: eur("10.00")},
+		{ID: "tr_2", Method: mollie.IDeal, Amount: eur("20.00")},
+		{
+			ID:               "tr_3",
+			Method:           mollie.CreditCard,
+			Amount:           &mollie.Amount{Currency: "USD", Value: "110.00"},
+			SettlementAmount: eur("100.00"),
+			Details:          &mollie.PaymentDetails{FeeRegion: mollie.IntraEU},
+		},
+	}
+
+	fee, err := e.EstimatePayment(payments[2])
+	require.Nil(t, err)
+	assert.Equal(t, "2.05", fee.Value)
+
+	estimates, err := e.EstimatePayments(payments)
+	require.Nil(t, err)
+	assert.Equal(t, []Estimate{
+		{Method: mollie.CreditCard, Count: 1, Fee: eur("2.05")},
+		{Method: mollie.IDeal, Count: 2, Fee: eur("0.58")},
+	}, estimates)
+
+	_, err = e.EstimatePayments(append(payments, &mollie.Payment{ID: "tr_4", Method: mollie.PayPal, Amount: eur("1.00")}))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "tr_4")
+}
+
+func TestEstimator_Reconcile(t *testing.T) {
+	e := estimator(t)
+
+	st := &reconcile.Statement{}
+	require.Nil(t, json.Unmarshal([]byte(testdata.GetSettlementsResponse), &st.Settlement))
+
+	// the settlement charged six iDEAL payments 0.35 each.
+	for i := 0; i < 6; i++ {
+		st.Payments = append(st.Payments, &mollie.Payment{ID: fmt.Sprintf("tr_%d", i), Method: mollie.IDeal, Amount: eur("10.00")})
+	}
+
+	st.Payments = append(st.Payments, &mollie.Payment{ID: "tr_pp", Method: mollie.PayPal, Amount: eur("10.00")})
+
+	drifts, err := e.Reconcile(st)
+	require.Nil(t, err)
+	require.Len(t, drifts, 1)
+
+	d := drifts[0]
+	assert.Equal(t, mollie.IDeal, d.Method)
+	assert.Equal(t, 6, d.Count)
+	assert.Equal(t, 6, d.EstimatedCount)
+	assert.Equal(t, "1.74", d.Estimated.Value)
+	assert.Equal(t, "2.10", d.Actual.Value)
+	assert.Equal(t, "0.36", d.Difference.Value)
+	assert.True(t, d.RateChanged)
+	assert.True(t, d.Drifted())
+
+	e.pricing[mollie.IDeal][0].Fixed = eur("0.35")
+
+	drifts, err = e.Reconcile(st)
+	require.Nil(t, err)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "0.00", drifts[0].Difference.Value)
+	assert.False(t, drifts[0].RateChanged)
+	assert.False(t, drifts[0].Drifted())
+}
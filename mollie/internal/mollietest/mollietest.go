@@ -0,0 +1,49 @@
+// Package mollietest provides the client bootstrap shared by the tests of
+// the mollie sub packages.
+package mollietest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Token is the API token set in the environment for the test clients.
+const Token = "token_X12b31ggg23"
+
+// New returns a testing client talking to a fresh mux, ready to register
+// the handlers of a test.
+func New(t *testing.T) (*mollie.Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	return NewClient(t, mux), mux
+}
+
+// NewClient returns a testing client talking to a server backed by the
+// given handler. The server and the environment are restored when the
+// test ends.
+func NewClient(t *testing.T, handler http.Handler) *mollie.Client {
+	t.Helper()
+
+	t.Setenv(mollie.APITokenEnv, Token)
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := mollie.NewClient(nil, mollie.NewConfig(true, mollie.APITokenEnv))
+	if err != nil {
+		t.Fatalf("creating the client: %v", err)
+	}
+
+	client.BaseURL, err = url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing the server url: %v", err)
+	}
+
+	return client
+}
@@ -3,7 +3,18 @@ package mollie
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+var (
+	errNoInvoicePDF       = errors.New("the invoice has no pdf link")
+	errInvoiceLineAmount  = errors.New("the line item has no amount")
+	errInvoiceCurrencyMix = errors.New("line items with the same period and vat percentage use different currencies")
 )
 
 // InvoiceStatus status of the invoice.
@@ -23,14 +34,16 @@ type Invoice struct {
 	Reference   string        `json:"reference,omitempty"`
 	VatNumber   string        `json:"vatNumber,omitempty"`
 	Status      InvoiceStatus `json:"status,omitempty"`
-	IssuedAt    string        `json:"issuedAt,omitempty"`
-	PaidAt      string        `json:"paidAt,omitempty"`
-	DueAt       string        `json:"dueAt,omitempty"`
+	IssuedAt    *ShortDate    `json:"issuedAt,omitempty"`
+	PaidAt      *ShortDate    `json:"paidAt,omitempty"`
+	DueAt       *ShortDate    `json:"dueAt,omitempty"`
 	NetAmount   *Amount       `json:"netAmount,omitempty"`
 	VatAmount   *Amount       `json:"vatAmount,omitempty"`
 	GrossAmount *Amount       `json:"grossAmount,omitempty"`
 	Lines       []*LineItem   `json:"lines,omitempty"`
 	Links       InvoiceLinks  `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // LineItem product details.
@@ -99,3 +112,123 @@ func (is *InvoicesService) List(ctx context.Context, options *InvoicesListOption
 
 	return
 }
+
+// DownloadPDF writes the PDF document of an invoice to w.
+//
+// The document is streamed from the pdf link of the invoice, the link
+// expires so the invoice should have been retrieved recently.
+func (is *InvoicesService) DownloadPDF(ctx context.Context, invoice *Invoice, w io.Writer) (res *Response, err error) {
+	if invoice == nil || invoice.Links.PDF == nil {
+		return nil, fmt.Errorf("invoice_error: %w", errNoInvoicePDF)
+	}
+
+	return is.client.download(ctx, invoice.Links.PDF, w)
+}
+
+// LineItemTotal aggregates the line items of one period charged at the
+// same VAT percentage.
+//
+// Amount is the sum of the net line amounts, VAT is calculated over
+// that sum and rounded to the decimals of the currency.
+type LineItemTotal struct {
+	Period        string
+	VatPercentage float64
+	Count         int64
+	Amount        *Amount
+	VAT           *Amount
+	Gross         *Amount
+}
+
+// Totals aggregates the lines of the invoice by period and VAT
+// percentage.
+func (i *Invoice) Totals() ([]LineItemTotal, error) {
+	return AggregateLineItems(i.Lines)
+}
+
+// AggregateLineItems groups line items, possibly from several invoices,
+// by period and VAT percentage. The totals are sorted by period and then
+// by VAT percentage.
+func AggregateLineItems(lines []*LineItem) ([]LineItemTotal, error) {
+	type key struct {
+		period string
+		vat    float64
+	}
+
+	type total struct {
+		count    int64
+		currency string
+		amount   *big.Rat
+	}
+
+	var keys []key
+
+	totals := map[key]*total{}
+
+	for _, l := range lines {
+		if l == nil {
+			continue
+		}
+
+		if l.Amount == nil {
+			return nil, fmt.Errorf("invoice_error: %s: %w", l.Description, errInvoiceLineAmount)
+		}
+
+		v, err := l.Amount.Rat()
+		if err != nil {
+			return nil, fmt.Errorf("invoice_error: %w", err)
+		}
+
+		k := key{l.Period, l.VatPercentage}
+
+		t, ok := totals[k]
+		if !ok {
+			t = &total{currency: l.Amount.Currency, amount: new(big.Rat)}
+			totals[k] = t
+			keys = append(keys, k)
+		}
+
+		if t.currency != l.Amount.Currency {
+			return nil, fmt.Errorf("invoice_error: %s: %w", l.Period, errInvoiceCurrencyMix)
+		}
+
+		t.count += l.Count
+		t.amount.Add(t.amount, v)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].period != keys[j].period {
+			return keys[i].period < keys[j].period
+		}
+
+		return keys[i].vat < keys[j].vat
+	})
+
+	res := make([]LineItemTotal, 0, len(keys))
+
+	for _, k := range keys {
+		t := totals[k]
+
+		pct, _ := new(big.Rat).SetString(strconv.FormatFloat(k.vat, 'f', -1, 64))
+		vat := new(big.Rat).Mul(t.amount, pct)
+		vat.Quo(vat, big.NewRat(100, 1))
+
+		net := AmountFromRat(t.currency, t.amount)
+		tax := AmountFromRat(t.currency, vat)
+
+		// the gross amount is derived from the rounded values so it always
+		// matches their sum.
+		n, _ := net.Rat()
+		v, _ := tax.Rat()
+
+		res = append(res, LineItemTotal{
+			Period:        k.period,
+			VatPercentage: k.vat,
+			Count:         t.count,
+			Amount:        net,
+			VAT:           tax,
+			Gross:         AmountFromRat(t.currency, n.Add(n, v)),
+		})
+	}
+
+	return res, nil
+}
@@ -1,7 +1,9 @@
 package mollie
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -231,6 +233,127 @@ func (is *invoiceServiceSuite) TestInvoicesService_List() {
 	}
 }
 
+func (is *invoiceServiceSuite) TestInvoicesService_DownloadPDF() {
+	cases := []struct {
+		name    string
+		invoice func() *Invoice
+		wantErr bool
+		err     error
+		handler http.HandlerFunc
+	}{
+		{
+			"download invoice pdf works as expected",
+			func() *Invoice {
+				return &Invoice{Links: InvoiceLinks{PDF: &URL{
+					Href: tServer.URL + "/merchant/download/invoice/xBEbP9rvAq/2ab44d60b35b",
+					Type: "application/pdf",
+				}}}
+			},
+			false,
+			nil,
+			func(w http.ResponseWriter, r *http.Request) {
+				testMethod(is.T(), r, "GET")
+				testHeader(is.T(), r, "Accept", "application/pdf")
+				testHeader(is.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				w.Header().Set("Content-Type", "application/pdf")
+				_, _ = w.Write([]byte("%PDF-1.4"))
+			},
+		},
+		{
+			"download invoice pdf, the invoice has no pdf link",
+			func() *Invoice { return &Invoice{} },
+			true,
+			fmt.Errorf("invoice_error: %w", errNoInvoicePDF),
+			errorHandler,
+		},
+		{
+			"download invoice pdf, an error is returned from the server",
+			func() *Invoice {
+				return &Invoice{Links: InvoiceLinks{PDF: &URL{
+					Href: tServer.URL + "/merchant/download/invoice/xBEbP9rvAq/2ab44d60b35b",
+				}}}
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+		is.T().Run(c.name, func(t *testing.T) {
+			tMux.HandleFunc("/merchant/download/invoice/xBEbP9rvAq/2ab44d60b35b", c.handler)
+
+			var buf bytes.Buffer
+
+			res, err := tClient.Invoices.DownloadPDF(context.Background(), c.invoice(), &buf)
+			if c.wantErr {
+				is.NotNil(err)
+				is.EqualError(err, c.err.Error())
+			} else {
+				is.Nil(err)
+				is.Equal("%PDF-1.4", buf.String())
+				is.Equal("application/pdf", res.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func (is *invoiceServiceSuite) TestInvoice_Dates() {
+	var i Invoice
+	is.Nil(json.Unmarshal([]byte(testdata.GetInvoiceResponse), &i))
+
+	is.Equal("2016-08-31", i.IssuedAt.Format("2006-01-02"))
+	is.Equal("2016-09-14", i.DueAt.Format("2006-01-02"))
+	is.Nil(i.PaidAt)
+}
+
+func (is *invoiceServiceSuite) TestAggregateLineItems() {
+	eur := func(v string) *Amount { return &Amount{Currency: "EUR", Value: v} }
+
+	lines := []*LineItem{
+		{Period: "2016-09", Description: "iDEAL transactiekosten", Count: 100, VatPercentage: 21, Amount: eur("29.00")},
+		{Period: "2016-08", Description: "iDEAL transactiekosten", Count: 10, VatPercentage: 21, Amount: eur("2.90")},
+		{Period: "2016-09", Description: "Creditcard transactiekosten", Count: 3, VatPercentage: 21, Amount: eur("0.75")},
+		{Period: "2016-09", Description: "Bankoverschrijving", Count: 2, VatPercentage: 0, Amount: eur("0.50")},
+	}
+
+	totals, err := AggregateLineItems(lines)
+	is.Nil(err)
+	is.Len(totals, 3)
+
+	is.Equal("2016-08", totals[0].Period)
+	is.Equal("2.90", totals[0].Amount.Value)
+	is.Equal("0.61", totals[0].VAT.Value)
+
+	is.Equal(float64(0), totals[1].VatPercentage)
+	is.Equal("0.00", totals[1].VAT.Value)
+	is.Equal("0.50", totals[1].Gross.Value)
+
+	is.Equal(int64(103), totals[2].Count)
+	is.Equal("29.75", totals[2].Amount.Value)
+	is.Equal("6.25", totals[2].VAT.Value)
+	is.Equal("36.00", totals[2].Gross.Value)
+
+	_, err = AggregateLineItems([]*LineItem{{Period: "2016-09", Description: "iDEAL"}})
+	is.ErrorIs(err, errInvoiceLineAmount)
+
+	_, err = AggregateLineItems([]*LineItem{
+		{Period: "2016-09", VatPercentage: 21, Amount: eur("1.00")},
+		{Period: "2016-09", VatPercentage: 21, Amount: &Amount{Currency: "GBP", Value: "1.00"}},
+	})
+	is.ErrorIs(err, errInvoiceCurrencyMix)
+
+	var i Invoice
+	is.Nil(json.Unmarshal([]byte(testdata.GetInvoiceResponse), &i))
+
+	totals, err = i.Totals()
+	is.Nil(err)
+	is.Len(totals, 1)
+	is.Equal("54.45", totals[0].Gross.Value)
+}
+
 func TestInvoicesService(t *testing.T) {
 	suite.Run(t, new(invoiceServiceSuite))
 }
@@ -0,0 +1,316 @@
+package mollie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// pageRequest returns the uri and query options of the page of a list
+// endpoint starting at from.
+type pageRequest func(from string) (uri string, options interface{})
+
+// pageOpener requests a page and returns a decoder over its resources.
+type pageOpener[T any] func(ctx context.Context, from string) (*Response, *pageDecoder[T], error)
+
+// Iterator walks over all the resources of a paginated list endpoint,
+// requesting the following page using the cursor contained in the
+// next link once the current page is exhausted.
+//
+// When the client config enables streaming, resources are decoded one
+// at a time from the response body, the page is never held in memory
+// as a whole.
+//
+// An iterator is not safe for concurrent use.
+//
+//	it := client.Mandates.Iterate("cst_8wmqcHMN4U", nil)
+//	for it.Next(ctx) {
+//		m := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle the error
+//	}
+type Iterator[T any] struct {
+	open    pageOpener[T]
+	page    *pageDecoder[T]
+	res     *Response
+	keep    func(T) bool
+	current T
+	from    string
+	done    bool
+	err     error
+}
+
+// newListIterator returns an iterator over a list endpoint of the API,
+// it streams the pages when the client config enables it.
+func newListIterator[T any](c *Client, request pageRequest) *Iterator[T] {
+	streaming := c.config != nil && c.config.streaming
+
+	return &Iterator[T]{open: func(ctx context.Context, from string) (*Response, *pageDecoder[T], error) {
+		uri, options := request(from)
+
+		if streaming {
+			res, err := c.stream(ctx, uri, options)
+			if err != nil {
+				return res, nil, err
+			}
+
+			return res, newPageDecoder[T](res.Body, res.Body), nil
+		}
+
+		res, err := c.get(ctx, uri, options)
+		if err != nil {
+			return res, nil, err
+		}
+
+		return res, newPageDecoder[T](bytes.NewReader(res.content), nil), nil
+	}}
+}
+
+// Next advances the iterator to the following resource, requesting a new
+// page when needed. It returns false when there are no more resources or
+// an error occurred, use Err to tell both situations apart.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.err == nil {
+		if it.page == nil {
+			if it.done {
+				return false
+			}
+
+			it.res, it.page, it.err = it.open(ctx, it.from)
+
+			continue
+		}
+
+		v, ok, err := it.page.next()
+		if err != nil {
+			it.err = err
+			_ = it.Close()
+
+			return false
+		}
+
+		if !ok {
+			it.from = nextCursor(it.page.links)
+			it.done = it.from == ""
+			_ = it.Close()
+
+			continue
+		}
+
+		if it.keep != nil && !it.keep(v) {
+			continue
+		}
+
+		it.current = v
+
+		return true
+	}
+
+	return false
+}
+
+// Value returns the resource the iterator currently points to.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the first error found while requesting or decoding
+// a page.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response of the last page requested by the
+// iterator, nil before the first page. The body of the response is
+// consumed by the iterator.
+func (it *Iterator[T]) Response() *Response {
+	return it.res
+}
+
+// Close releases the page being read, it is only required when a
+// streaming iterator is abandoned before Next returns false.
+func (it *Iterator[T]) Close() error {
+	if it.page == nil {
+		return nil
+	}
+
+	err := it.page.Close()
+	it.page = nil
+
+	return err
+}
+
+// All consumes the iterator and returns the remaining resources.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+
+	return all, it.Err()
+}
+
+// nextCursor extracts the from parameter used by Mollie as pagination
+// cursor out of the next link of a list response.
+func nextCursor(links PaginationLinks) string {
+	if links.Next == nil || links.Next.Href == "" {
+		return ""
+	}
+
+	u, err := url.Parse(links.Next.Href)
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("from")
+}
+
+var errPageFormat = errors.New("unexpected list response format")
+
+// decoding states of a list page.
+const (
+	pageStart = iota
+	pageObject
+	pageEmbedded
+	pageItems
+	pageEnd
+)
+
+// pageDecoder reads the resources of a list response one at a time.
+//
+// The resources are read from the arrays of the _embedded object, the
+// pagination links are kept once decoded and other members are skipped.
+type pageDecoder[T any] struct {
+	dec    *json.Decoder
+	closer io.Closer
+	state  int
+	links  PaginationLinks
+}
+
+func newPageDecoder[T any](r io.Reader, closer io.Closer) *pageDecoder[T] {
+	return &pageDecoder[T]{dec: json.NewDecoder(r), closer: closer}
+}
+
+// next decodes the following resource of the page, ok is false once the
+// whole page has been read.
+func (p *pageDecoder[T]) next() (v T, ok bool, err error) {
+	for {
+		switch p.state {
+		case pageItems:
+			if p.dec.More() {
+				if err = p.dec.Decode(&v); err != nil {
+					return v, false, err
+				}
+
+				return v, true, nil
+			}
+
+			if _, err = p.dec.Token(); err != nil {
+				return v, false, err
+			}
+
+			p.state = pageEmbedded
+		case pageEnd:
+			return v, false, nil
+		default:
+			if err = p.advance(); err != nil {
+				return v, false, err
+			}
+		}
+	}
+}
+
+// advance reads the page up to the next state change.
+func (p *pageDecoder[T]) advance() error {
+	tok, err := p.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch p.state {
+	case pageStart:
+		if tok != json.Delim('{') {
+			return fmt.Errorf("%w: %v", errPageFormat, tok)
+		}
+
+		p.state = pageObject
+	case pageObject:
+		switch tok {
+		case json.Delim('}'):
+			p.state = pageEnd
+		case "_links":
+			return p.dec.Decode(&p.links)
+		case "_embedded":
+			if tok, err = p.dec.Token(); err != nil {
+				return err
+			}
+
+			if tok != json.Delim('{') {
+				return p.skip(tok)
+			}
+
+			p.state = pageEmbedded
+		default:
+			return p.dec.Decode(&json.RawMessage{})
+		}
+	case pageEmbedded:
+		if tok == json.Delim('}') {
+			p.state = pageObject
+
+			return nil
+		}
+
+		// tok is a member name, the resources are in the first array.
+		if tok, err = p.dec.Token(); err != nil {
+			return err
+		}
+
+		if tok == json.Delim('[') {
+			p.state = pageItems
+
+			return nil
+		}
+
+		return p.skip(tok)
+	}
+
+	return nil
+}
+
+// skip consumes the rest of a value whose first token was tok.
+func (p *pageDecoder[T]) skip(tok json.Token) error {
+	if tok != json.Delim('{') && tok != json.Delim('[') {
+		return nil
+	}
+
+	for depth := 1; depth > 0; {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+
+	return nil
+}
+
+// Close closes the response body the page is read from.
+func (p *pageDecoder[T]) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+
+	return p.closer.Close()
+}
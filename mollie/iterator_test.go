@@ -0,0 +1,319 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagesHandler serves the items of pages as a list endpoint, the pages
+// are indexed by the cursor requesting them.
+func pagesHandler(t *testing.T, pages map[string][]int, next map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from := r.URL.Query().Get("from")
+
+		items, ok := pages[from]
+		if !ok {
+			t.Errorf("unexpected page requested: %q", from)
+		}
+
+		page := map[string]interface{}{
+			"count":     len(items),
+			"_embedded": map[string][]int{"items": items},
+			"_links":    PaginationLinks{},
+		}
+
+		if n := next[from]; n != "" {
+			page["_links"] = PaginationLinks{Next: &URL{Href: "https://api.mollie.com/v2/items?from=" + n + "&limit=2"}}
+		}
+
+		_ = json.NewEncoder(w).Encode(page)
+	}
+}
+
+func itemsRequest(from string) (string, interface{}) {
+	return "v2/items", &struct {
+		From string `url:"from,omitempty"`
+	}{from}
+}
+
+func TestIterator_Next(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	cases := []struct {
+		name  string
+		pages map[string][]int
+		next  map[string]string
+		want  []int
+	}{
+		{
+			"single page",
+			map[string][]int{"": {1, 2}},
+			nil,
+			[]int{1, 2},
+		},
+		{
+			"several pages",
+			map[string][]int{"": {1, 2}, "r3": {3, 4}, "r5": {5}},
+			map[string]string{"": "r3", "r3": "r5"},
+			[]int{1, 2, 3, 4, 5},
+		},
+		{
+			"empty pages are skipped",
+			map[string][]int{"": {}, "r1": {1}},
+			map[string]string{"": "r1"},
+			[]int{1},
+		},
+		{
+			"empty list",
+			map[string][]int{"": nil},
+			nil,
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			tMux.HandleFunc("/v2/items", pagesHandler(t, c.pages, c.next))
+
+			it := newListIterator[int](tClient, itemsRequest)
+
+			got, err := it.All(context.Background())
+			require.Nil(t, err)
+			assert.Equal(t, c.want, got)
+			assert.False(t, it.Next(context.Background()))
+		})
+	}
+}
+
+func TestIterator_Filter(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/items", pagesHandler(t,
+		map[string][]int{"": {1, 2, 3}, "r4": {4, 5, 6}},
+		map[string]string{"": "r4"},
+	))
+
+	it := newListIterator[int](tClient, itemsRequest)
+	it.keep = func(v int) bool { return v%2 == 0 }
+
+	got, err := it.All(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, []int{2, 4, 6}, got)
+}
+
+func TestIterator_Err(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	calls := 0
+	pages := pagesHandler(t, map[string][]int{"": {1}}, map[string]string{"": "r2"})
+
+	tMux.HandleFunc("/v2/items", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("from") != "" {
+			errorHandler(w, r)
+
+			return
+		}
+
+		pages(w, r)
+	})
+
+	it := newListIterator[int](tClient, itemsRequest)
+
+	ctx := context.Background()
+	require.True(t, it.Next(ctx))
+	assert.Equal(t, 1, it.Value())
+	assert.False(t, it.Next(ctx))
+	assert.False(t, it.Next(ctx))
+	assert.EqualError(t, it.Err(), "500 Internal Server Error: An internal server error occurred while processing your request.")
+	assert.Equal(t, 2, calls)
+}
+
+func TestNextCursor(t *testing.T) {
+	cases := []struct {
+		name  string
+		links PaginationLinks
+		want  string
+	}{
+		{"no next link", PaginationLinks{}, ""},
+		{"empty href", PaginationLinks{Next: &URL{}}, ""},
+		{"malformed href", PaginationLinks{Next: &URL{Href: "http://[::1]:namedport"}}, ""},
+		{
+			"next link with cursor",
+			PaginationLinks{Next: &URL{Href: "https://api.mollie.com/v2/payments?from=tr_SDkzMggpvx&limit=5"}},
+			"tr_SDkzMggpvx",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, nextCursor(c.links))
+		})
+	}
+}
+
+func TestPageDecoder(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+
+	cases := []struct {
+		name string
+		body string
+		want []string
+		next string
+	}{
+		{
+			"embedded before links",
+			`{"count": 2, "_embedded": {"items": [{"id": "a"}, {"id": "b"}]}, "_links": {"next": {"href": "https://api.mollie.com/v2/items?from=c"}}}`,
+			[]string{"a", "b"},
+			"https://api.mollie.com/v2/items?from=c",
+		},
+		{
+			"links before embedded",
+			`{"_links": {"next": null}, "_embedded": {"items": [{"id": "a", "nested": {"list": [1, 2]}}]}, "count": 1}`,
+			[]string{"a"},
+			"",
+		},
+		{
+			"members of the embedded object that are not lists",
+			`{"_embedded": {"meta": {"total": [3]}, "total": 3, "items": [{"id": "a"}]}}`,
+			[]string{"a"},
+			"",
+		},
+		{
+			"empty page",
+			`{"count": 0, "_embedded": {"items": []}, "_links": {}}`,
+			nil,
+			"",
+		},
+		{
+			"no embedded resources",
+			`{"count": 0, "_embedded": null}`,
+			nil,
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := newPageDecoder[*item](strings.NewReader(c.body), nil)
+
+			var got []string
+
+			for {
+				v, ok, err := p.next()
+				require.Nil(t, err)
+
+				if !ok {
+					break
+				}
+
+				got = append(got, v.ID)
+			}
+
+			assert.Equal(t, c.want, got)
+
+			if c.next == "" {
+				assert.Nil(t, p.links.Next)
+			} else {
+				assert.Equal(t, c.next, p.links.Next.Href)
+			}
+		})
+	}
+
+	t.Run("malformed responses", func(t *testing.T) {
+		for _, body := range []string{`[]`, `{"_embedded": {"items": [{"id": 1}]}}`, `{"_embedded": {"items": [`} {
+			p := newPageDecoder[*item](strings.NewReader(body), nil)
+
+			var err error
+			for ok := true; ok && err == nil; {
+				_, ok, err = p.next()
+			}
+
+			assert.NotNil(t, err, body)
+		}
+	})
+}
+
+func TestIterator_Streaming(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+
+		if r.URL.Query().Get("from") == "" {
+			_, _ = w.Write([]byte(testdata.ListSettlementPaymentsResponse))
+			return
+		}
+
+		_, _ = w.Write([]byte(testdata.ListSettlementPaymentsLastPageResponse))
+	}
+
+	for _, streaming := range []bool{false, true} {
+		setup()
+		tConf.WithStreaming(streaming)
+		tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/payments", handler)
+
+		it := tClient.Settlements.IteratePayments("stl_jDk30akdN", &SettlementsListOptions{Limit: 4})
+		assert.Nil(t, it.Response())
+
+		payments, err := it.All(context.Background())
+		require.Nil(t, err)
+		assert.Len(t, payments, 6)
+		assert.Equal(t, "tr_CYkNOKqkFc", payments[5].ID)
+		assert.Equal(t, http.StatusOK, it.Response().StatusCode)
+		assert.Equal(t, "application/hal+json", it.Response().Header.Get("Content-Type"))
+		assert.Nil(t, it.Close())
+
+		teardown()
+	}
+}
+
+func TestIterator_StreamingErrors(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	tConf.WithStreaming(true)
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/payments", errorHandler)
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/refunds", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.ListSettlementRefundsResponse))
+	})
+
+	ctx := context.Background()
+
+	it := tClient.Settlements.IteratePayments("stl_jDk30akdN", nil)
+	assert.False(t, it.Next(ctx))
+	assert.EqualError(t, it.Err(), "500 Internal Server Error: An internal server error occurred while processing your request.")
+	assert.Equal(t, http.StatusInternalServerError, it.Response().StatusCode)
+
+	it2 := tClient.Settlements.IterateRefunds("stl_jDk30akdN", nil)
+	require.True(t, it2.Next(ctx))
+	assert.NotNil(t, it2.page)
+	assert.Nil(t, it2.Close())
+	assert.Nil(t, it2.page)
+}
@@ -0,0 +1,361 @@
+// Package mandatehealth finds the mandates that are about to break
+// recurring billing before a charge fails.
+//
+// A Scanner walks every customer, loading their mandates and
+// subscriptions with bounded concurrency, and produces a Report listing
+// expiring cards, invalid mandates, mandates pending for too long and
+// active subscriptions relying on an unusable mandate.
+//
+//	report, err := mandatehealth.NewScanner(client, &mandatehealth.Options{ExpiryDays: 45}).Scan(ctx)
+//	if err != nil {
+//		// the customers could not be listed.
+//	}
+//
+//	for _, f := range report.Findings {
+//		log.Println(f.Issue, f.CustomerID, f.MandateID)
+//	}
+package mandatehealth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Default scanner settings.
+const (
+	DefaultExpiryDays  = 30
+	DefaultPendingDays = 14
+	DefaultConcurrency = 4
+)
+
+// Issue describes the problem found on a mandate.
+type Issue string
+
+// Reported issues.
+const (
+	CardExpiring          Issue = "card_expiring"
+	CardExpired           Issue = "card_expired"
+	MandateInvalid        Issue = "mandate_invalid"
+	MandatePendingTooLong Issue = "mandate_pending_too_long"
+	SubscriptionUnusable  Issue = "subscription_mandate_unusable"
+)
+
+// Options configures a Scanner, zero values use the package defaults.
+type Options struct {
+	// ExpiryDays reports card mandates expiring within this amount of days.
+	ExpiryDays int
+	// PendingDays reports mandates pending for longer than this amount of days.
+	PendingDays int
+	// Concurrency limits the amount of customers scanned at the same time.
+	Concurrency int
+	// Customers filters the scanned customers, e.g. by profile.
+	Customers *mollie.CustomersListOptions
+}
+
+// Finding is a single problem detected during a scan.
+type Finding struct {
+	Issue          Issue      `json:"issue"`
+	CustomerID     string     `json:"customerId"`
+	MandateID      string     `json:"mandateId,omitempty"`
+	SubscriptionID string     `json:"subscriptionId,omitempty"`
+	Method         string     `json:"method,omitempty"`
+	Date           *time.Time `json:"date,omitempty"`
+	Detail         string     `json:"detail"`
+}
+
+// CustomerError records a customer whose mandates or subscriptions
+// could not be loaded, the rest of the scan continues.
+type CustomerError struct {
+	CustomerID string `json:"customerId"`
+	Err        string `json:"error"`
+}
+
+// Report is the outcome of a scan, it can be serialized as JSON to
+// feed alerting or e-mail templates.
+type Report struct {
+	GeneratedAt      time.Time       `json:"generatedAt"`
+	CustomersScanned int             `json:"customersScanned"`
+	MandatesScanned  int             `json:"mandatesScanned"`
+	Findings         []Finding       `json:"findings"`
+	Errors           []CustomerError `json:"errors,omitempty"`
+}
+
+// Healthy reports whether the scan found no problems and no errors.
+func (r *Report) Healthy() bool {
+	return len(r.Findings) == 0 && len(r.Errors) == 0
+}
+
+// ByIssue returns the findings of the given issue.
+func (r *Report) ByIssue(issue Issue) []Finding {
+	var found []Finding
+
+	for _, f := range r.Findings {
+		if f.Issue == issue {
+			found = append(found, f)
+		}
+	}
+
+	return found
+}
+
+// Summary counts the findings of every issue.
+func (r *Report) Summary() map[Issue]int {
+	s := make(map[Issue]int)
+	for _, f := range r.Findings {
+		s[f.Issue]++
+	}
+
+	return s
+}
+
+// Scanner inspects the mandates and subscriptions of every customer.
+type Scanner struct {
+	client *mollie.Client
+	opts   Options
+	now    func() time.Time
+}
+
+// NewScanner creates a scanner using the given client, a nil options
+// value uses the defaults.
+func NewScanner(client *mollie.Client, opts *Options) *Scanner {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.ExpiryDays <= 0 {
+		o.ExpiryDays = DefaultExpiryDays
+	}
+
+	if o.PendingDays <= 0 {
+		o.PendingDays = DefaultPendingDays
+	}
+
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+
+	return &Scanner{client: client, opts: o, now: time.Now}
+}
+
+// Scan walks all the customers and returns the report. An error is only
+// returned when the customers can't be listed or ctx is done, failures
+// loading a single customer are recorded in Report.Errors.
+func (s *Scanner) Scan(ctx context.Context) (*Report, error) {
+	report := &Report{GeneratedAt: s.now()}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	customers := make(chan string)
+
+	for i := 0; i < s.opts.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for id := range customers {
+				findings, mandates, err := s.scanCustomer(ctx, id, report.GeneratedAt)
+
+				mu.Lock()
+				report.MandatesScanned += mandates
+				report.Findings = append(report.Findings, findings...)
+
+				if err != nil {
+					report.Errors = append(report.Errors, CustomerError{CustomerID: id, Err: err.Error()})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	it := s.client.Customers.Iterate(s.opts.Customers)
+
+	var err error
+
+loop:
+	for it.Next(ctx) {
+		select {
+		case customers <- it.Value().ID:
+			report.CustomersScanned++
+		case <-ctx.Done():
+			err = ctx.Err()
+
+			break loop
+		}
+	}
+
+	close(customers)
+	wg.Wait()
+
+	if err == nil {
+		err = it.Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.CustomerID != b.CustomerID {
+			return a.CustomerID < b.CustomerID
+		}
+
+		return a.Issue < b.Issue
+	})
+
+	sort.Slice(report.Errors, func(i, j int) bool {
+		return report.Errors[i].CustomerID < report.Errors[j].CustomerID
+	})
+
+	return report, nil
+}
+
+func (s *Scanner) scanCustomer(ctx context.Context, customer string, now time.Time) ([]Finding, int, error) {
+	mandates, err := s.client.Mandates.Iterate(customer, nil).All(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var findings []Finding
+
+	byID := make(map[string]*mollie.Mandate, len(mandates))
+	usable := false
+
+	for _, m := range mandates {
+		byID[m.ID] = m
+
+		if f, ok := s.checkMandate(customer, m, now); ok {
+			findings = append(findings, f)
+		}
+
+		if mandateUsable(m, now) {
+			usable = true
+		}
+	}
+
+	subscriptions, err := s.client.Subscriptions.Iterate(customer, nil).All(ctx)
+	if err != nil {
+		return findings, len(mandates), err
+	}
+
+	for _, sub := range subscriptions {
+		if sub.Status != mollie.SubscriptionStatusActive {
+			continue
+		}
+
+		f := Finding{
+			Issue:          SubscriptionUnusable,
+			CustomerID:     customer,
+			MandateID:      sub.MandateID,
+			SubscriptionID: sub.ID,
+			Method:         string(sub.Method),
+		}
+
+		switch m, ok := byID[sub.MandateID]; {
+		case sub.MandateID == "" && !usable:
+			f.Detail = "the customer has no usable mandate to charge"
+		case sub.MandateID == "":
+			continue
+		case !ok:
+			f.Detail = "the subscription mandate does not exist"
+		case !mandateUsable(m, now):
+			f.Detail = "the subscription mandate is " + unusableReason(m, now)
+		default:
+			continue
+		}
+
+		findings = append(findings, f)
+	}
+
+	return findings, len(mandates), nil
+}
+
+// checkMandate returns the finding of a single mandate, if any.
+func (s *Scanner) checkMandate(customer string, m *mollie.Mandate, now time.Time) (Finding, bool) {
+	f := Finding{
+		CustomerID: customer,
+		MandateID:  m.ID,
+		Method:     string(m.Method),
+	}
+
+	switch m.Status {
+	case mollie.InvalidMandate:
+		f.Issue, f.Detail = MandateInvalid, "the mandate is invalid"
+
+		return f, true
+	case mollie.PendingMandate:
+		since := pendingSince(m)
+		if since == nil || now.Sub(*since) <= days(s.opts.PendingDays) {
+			return f, false
+		}
+
+		f.Issue, f.Date, f.Detail = MandatePendingTooLong, since, "the mandate is pending since "+since.Format("2006-01-02")
+
+		return f, true
+	}
+
+	expiry := m.Details.CardExpiryDate
+	if expiry == nil {
+		return f, false
+	}
+
+	date := expiry.Time
+	f.Date = &date
+
+	switch {
+	case expired(m, now):
+		f.Issue, f.Detail = CardExpired, "the card expired on "+date.Format("2006-01-02")
+	case date.Sub(now) <= days(s.opts.ExpiryDays):
+		f.Issue, f.Detail = CardExpiring, "the card expires on "+date.Format("2006-01-02")
+	default:
+		return f, false
+	}
+
+	return f, true
+}
+
+// mandateUsable reports whether Mollie can charge the mandate, pending
+// mandates are accepted when creating recurring payments.
+func mandateUsable(m *mollie.Mandate, now time.Time) bool {
+	return m.Status != mollie.InvalidMandate && !expired(m, now)
+}
+
+func unusableReason(m *mollie.Mandate, now time.Time) string {
+	if expired(m, now) {
+		return "linked to an expired card"
+	}
+
+	return "invalid"
+}
+
+// expired reports whether the card of a mandate is past its expiry
+// date, cards are valid until the end of the expiry date.
+func expired(m *mollie.Mandate, now time.Time) bool {
+	e := m.Details.CardExpiryDate
+
+	return e != nil && now.After(e.AddDate(0, 0, 1))
+}
+
+func pendingSince(m *mollie.Mandate) *time.Time {
+	switch {
+	case m.CreatedAt != nil:
+		return m.CreatedAt
+	case m.SignatureDate != nil:
+		return &m.SignatureDate.Time
+	}
+
+	return nil
+}
+
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
@@ -0,0 +1,181 @@
+package mandatehealth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customersPage = `{
+    "count": 3,
+    "_embedded": {
+        "customers": [
+            {"resource": "customer", "id": "cst_healthy"},
+            {"resource": "customer", "id": "cst_expiring"},
+            {"resource": "customer", "id": "cst_broken"}
+        ]
+    },
+    "_links": {"next": {"href": "https://api.mollie.com/v2/customers?from=cst_failing&limit=3"}}
+}`
+
+const customersLastPage = `{
+    "count": 1,
+    "_embedded": {
+        "customers": [
+            {"resource": "customer", "id": "cst_failing"}
+        ]
+    },
+    "_links": {"next": null}
+}`
+
+var mandates = map[string]string{
+	"cst_healthy": `{
+        "count": 1,
+        "_embedded": {"mandates": [
+            {"resource": "mandate", "id": "mdt_valid", "status": "valid", "method": "creditcard",
+             "details": {"cardExpiryDate": "2024-12-31"}}
+        ]},
+        "_links": {}
+    }`,
+	"cst_expiring": `{
+        "count": 2,
+        "_embedded": {"mandates": [
+            {"resource": "mandate", "id": "mdt_expiring", "status": "valid", "method": "creditcard",
+             "details": {"cardExpiryDate": "2023-03-31"}},
+            {"resource": "mandate", "id": "mdt_pending", "status": "pending", "method": "directdebit",
+             "createdAt": "2023-03-10T10:00:00+00:00"}
+        ]},
+        "_links": {}
+    }`,
+	"cst_broken": `{
+        "count": 3,
+        "_embedded": {"mandates": [
+            {"resource": "mandate", "id": "mdt_expired", "status": "valid", "method": "creditcard",
+             "details": {"cardExpiryDate": "2023-02-28"}},
+            {"resource": "mandate", "id": "mdt_invalid", "status": "invalid", "method": "directdebit"},
+            {"resource": "mandate", "id": "mdt_stale", "status": "pending", "method": "directdebit",
+             "createdAt": "2023-01-02T10:00:00+00:00"}
+        ]},
+        "_links": {}
+    }`,
+}
+
+var subscriptions = map[string]string{
+	"cst_healthy": `{
+        "count": 1,
+        "_embedded": {"subscriptions": [
+            {"resource": "subscription", "id": "sub_ok", "status": "active", "mandateId": "mdt_valid"}
+        ]},
+        "_links": {}
+    }`,
+	"cst_expiring": `{"count": 0, "_embedded": {"subscriptions": []}, "_links": {}}`,
+	"cst_broken": `{
+        "count": 3,
+        "_embedded": {"subscriptions": [
+            {"resource": "subscription", "id": "sub_expired", "status": "active", "mandateId": "mdt_expired"},
+            {"resource": "subscription", "id": "sub_missing", "status": "active", "mandateId": "mdt_gone"},
+            {"resource": "subscription", "id": "sub_canceled", "status": "canceled", "mandateId": "mdt_invalid"}
+        ]},
+        "_links": {}
+    }`,
+}
+
+func newScanner(t *testing.T, opts *Options) *Scanner {
+	t.Helper()
+
+	client, mux := mollietest.New(t)
+	mux.HandleFunc("/v2/customers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") == "cst_failing" {
+			_, _ = w.Write([]byte(customersLastPage))
+			return
+		}
+
+		_, _ = w.Write([]byte(customersPage))
+	})
+
+	for id := range mandates {
+		m, s := mandates[id], subscriptions[id]
+
+		mux.HandleFunc("/v2/customers/"+id+"/mandates", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(m))
+		})
+		mux.HandleFunc("/v2/customers/"+id+"/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(s))
+		})
+	}
+
+	mux.HandleFunc("/v2/customers/cst_failing/mandates", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	s := NewScanner(client, opts)
+	s.now = func() time.Time { return time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC) }
+
+	return s
+}
+
+func TestScanner_Scan(t *testing.T) {
+	report, err := newScanner(t, &Options{Concurrency: 2}).Scan(context.Background())
+	require.Nil(t, err)
+
+	assert.Equal(t, 4, report.CustomersScanned)
+	assert.Equal(t, 6, report.MandatesScanned)
+	assert.False(t, report.Healthy())
+
+	type key struct {
+		issue    Issue
+		customer string
+		mandate  string
+		sub      string
+	}
+
+	var got []key
+	for _, f := range report.Findings {
+		got = append(got, key{f.Issue, f.CustomerID, f.MandateID, f.SubscriptionID})
+	}
+
+	assert.Equal(t, []key{
+		{CardExpired, "cst_broken", "mdt_expired", ""},
+		{MandateInvalid, "cst_broken", "mdt_invalid", ""},
+		{MandatePendingTooLong, "cst_broken", "mdt_stale", ""},
+		{SubscriptionUnusable, "cst_broken", "mdt_expired", "sub_expired"},
+		{SubscriptionUnusable, "cst_broken", "mdt_gone", "sub_missing"},
+		{CardExpiring, "cst_expiring", "mdt_expiring", ""},
+	}, got)
+
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "cst_failing", report.Errors[0].CustomerID)
+
+	assert.Equal(t, 2, report.Summary()[SubscriptionUnusable])
+	assert.Len(t, report.ByIssue(CardExpiring), 1)
+}
+
+func TestScanner_ScanWindows(t *testing.T) {
+	report, err := newScanner(t, &Options{ExpiryDays: 7, PendingDays: 90}).Scan(context.Background())
+	require.Nil(t, err)
+
+	assert.Empty(t, report.ByIssue(CardExpiring))
+	assert.Empty(t, report.ByIssue(MandatePendingTooLong))
+	assert.Len(t, report.ByIssue(CardExpired), 1)
+}
+
+func TestScanner_ScanCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newScanner(t, nil).Scan(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewScanner_Defaults(t *testing.T) {
+	s := NewScanner(nil, nil)
+
+	assert.Equal(t, DefaultExpiryDays, s.opts.ExpiryDays)
+	assert.Equal(t, DefaultPendingDays, s.opts.PendingDays)
+	assert.Equal(t, DefaultConcurrency, s.opts.Concurrency)
+}
@@ -22,6 +22,8 @@ type Mandate struct {
 	CreatedAt        *time.Time     `json:"createdAt,omitempty"`
 	Details          MandateDetails `json:"details,omitempty"`
 	Links            MandateLinks   `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // MandateDetails are possible values inside the mandate.details field.
@@ -36,6 +38,53 @@ type MandateDetails struct {
 	CardExpiryDate  *ShortDate `json:"cardExpiryDate,omitempty"`
 }
 
+// MandateCardDetails are the details of credit card mandates.
+type MandateCardDetails struct {
+	CardHolder      string
+	CardNumber      string
+	CardLabel       CardLabel
+	CardFingerprint string
+	CardExpiryDate  *ShortDate
+}
+
+// MandateDirectDebitDetails are the details of SEPA direct debit
+// mandates.
+type MandateDirectDebitDetails struct {
+	ConsumerName    string
+	ConsumerAccount string
+	ConsumerBic     string
+}
+
+// CardDetails returns the details of a credit card mandate, nil for
+// other methods.
+func (m *Mandate) CardDetails() *MandateCardDetails {
+	if m.Method != CreditCard {
+		return nil
+	}
+
+	return &MandateCardDetails{
+		CardHolder:      m.Details.CardHolder,
+		CardNumber:      m.Details.CardNumber,
+		CardLabel:       m.Details.CardLabel,
+		CardFingerprint: m.Details.CardFingerprint,
+		CardExpiryDate:  m.Details.CardExpiryDate,
+	}
+}
+
+// DirectDebitDetails returns the details of a SEPA direct debit mandate,
+// nil for other methods.
+func (m *Mandate) DirectDebitDetails() *MandateDirectDebitDetails {
+	if m.Method != DirectDebit {
+		return nil
+	}
+
+	return &MandateDirectDebitDetails{
+		ConsumerName:    m.Details.ConsumerName,
+		ConsumerAccount: m.Details.ConsumerAccount,
+		ConsumerBic:     m.Details.ConsumerBic,
+	}
+}
+
 // MandateStatus for the Mandate object.
 type MandateStatus string
 
@@ -101,6 +150,10 @@ type MandatesList struct {
 //
 // See: https://docs.mollie.com/reference/v2/mandates-api/create-mandate
 func (ms *MandatesService) Create(ctx context.Context, customer string, mandate Mandate) (res *Response, mr *Mandate, err error) {
+	if err = ms.client.validate(&mandate); err != nil {
+		return
+	}
+
 	u := fmt.Sprintf("v2/customers/%s/mandates", customer)
 
 	res, err = ms.client.post(ctx, u, mandate, nil)
@@ -170,3 +223,21 @@ func (ms *MandatesService) List(ctx context.Context, customer string, options *M
 
 	return
 }
+
+// Iterate returns an iterator over all the mandates of the given customer.
+//
+// See: https://docs.mollie.com/reference/v2/mandates-api/list-mandates
+func (ms *MandatesService) Iterate(customer string, options *MandatesListOptions) *Iterator[*Mandate] {
+	return newListIterator[*Mandate](ms.client, func(from string) (string, interface{}) {
+		opts := MandatesListOptions{}
+		if options != nil {
+			opts = *options
+		}
+
+		if from != "" {
+			opts.From = from
+		}
+
+		return fmt.Sprintf("v2/customers/%s/mandates", customer), &opts
+	})
+}
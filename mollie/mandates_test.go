@@ -436,6 +436,46 @@ func (ms *mandateServiceSuite) TestMandatesService_List() {
 	}
 }
 
+func (ms *mandateServiceSuite) TestMandatesService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/mandates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ms.T(), r, "GET")
+
+		if r.URL.Query().Get("from") == "" {
+			testQuery(ms.T(), r, "limit=5&testmode=true")
+			_, _ = w.Write([]byte(testdata.ListMandatesResponse))
+			return
+		}
+
+		testQuery(ms.T(), r, "from=mdt_AcQl5fdL4h&limit=5&testmode=true")
+		_, _ = w.Write([]byte(testdata.ListMandatesLastPageResponse))
+	})
+
+	it := tClient.Mandates.Iterate("cst_8wmqcHMN4U", &MandatesListOptions{Limit: 5})
+
+	var statuses []MandateStatus
+	for it.Next(context.Background()) {
+		statuses = append(statuses, it.Value().Status)
+	}
+
+	ms.Nil(it.Err())
+	ms.Equal([]MandateStatus{ValidMandate, ValidMandate, PendingMandate, InvalidMandate}, statuses)
+}
+
+func (ms *mandateServiceSuite) TestMandatesService_IterateError() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/mandates", errorHandler)
+
+	it := tClient.Mandates.Iterate("cst_8wmqcHMN4U", nil)
+
+	ms.False(it.Next(context.Background()))
+	ms.EqualError(it.Err(), "500 Internal Server Error: An internal server error occurred while processing your request.")
+}
+
 func TestMandatesService(t *testing.T) {
 	suite.Run(t, new(mandateServiceSuite))
 }
@@ -0,0 +1,204 @@
+package mollie
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxMetadataSize is the maximum size in bytes of the JSON encoded
+// metadata Mollie accepts on a resource.
+const MaxMetadataSize = 1024
+
+// CorrelationIDKey is the metadata key holding the ID linking a resource
+// to a record of your own, e.g. an order or invoice number.
+const CorrelationIDKey = "correlationId"
+
+var (
+	errMetadataTooLarge = errors.New("metadata exceeds the limit of 1024 bytes")
+	errMetadataObject   = errors.New("metadata must be a JSON object")
+	errNoMetadata       = errors.New("the resource has no metadata")
+)
+
+// MetadataHolder is implemented by the resources carrying metadata:
+// payments, orders, order lines, refunds, customers and subscriptions.
+type MetadataHolder interface {
+	metadata() interface{}
+	setMetadata(b json.RawMessage) error
+}
+
+// EncodeMetadata returns the JSON encoding of v, checking it doesn't
+// exceed MaxMetadataSize.
+func EncodeMetadata(v interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("metadata_error: %w", err)
+	}
+
+	if len(b) > MaxMetadataSize {
+		return nil, fmt.Errorf("metadata_error: %d bytes: %w", len(b), errMetadataTooLarge)
+	}
+
+	return b, nil
+}
+
+// SetMetadata encodes v as the metadata of a resource, the encoding must
+// not exceed MaxMetadataSize. Customers and subscriptions only accept
+// values encoded as JSON objects.
+//
+//	err := mollie.SetMetadata(&p, OrderRef{ID: "1234", Channel: "web"})
+func SetMetadata(r MetadataHolder, v interface{}) error {
+	b, err := EncodeMetadata(v)
+	if err != nil {
+		return err
+	}
+
+	return r.setMetadata(b)
+}
+
+// DecodeMetadata decodes the metadata of a resource into a value of
+// type T, whatever the metadata field holds: the generic values decoded
+// from a response, a json.RawMessage or a value set by your code.
+//
+//	ref, err := mollie.DecodeMetadata[OrderRef](p.Metadata)
+func DecodeMetadata[T any](md interface{}) (v T, err error) {
+	b, err := metadataJSON(md)
+	if err != nil {
+		return v, err
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return v, fmt.Errorf("metadata_error: %w", err)
+	}
+
+	return v, nil
+}
+
+// SetCorrelationID stores id under CorrelationIDKey in the metadata of a
+// resource, keeping the other keys. The metadata must be empty or a JSON
+// object.
+func SetCorrelationID(r MetadataHolder, id string) error {
+	fields := map[string]json.RawMessage{}
+
+	if md := r.metadata(); !emptyMetadata(md) {
+		var err error
+
+		fields, err = DecodeMetadata[map[string]json.RawMessage](md)
+		if err != nil || fields == nil {
+			return fmt.Errorf("metadata_error: %w", errMetadataObject)
+		}
+	}
+
+	value, err := json.Marshal(id)
+	if err != nil {
+		return fmt.Errorf("metadata_error: %w", err)
+	}
+
+	fields[CorrelationIDKey] = value
+
+	return SetMetadata(r, fields)
+}
+
+// CorrelationID returns the ID stored under CorrelationIDKey in the
+// metadata, empty when there is none.
+func CorrelationID(md interface{}) string {
+	fields, err := DecodeMetadata[map[string]interface{}](md)
+	if err != nil {
+		return ""
+	}
+
+	id, _ := fields[CorrelationIDKey].(string)
+
+	return id
+}
+
+// metadataJSON returns the JSON encoding of md.
+func metadataJSON(md interface{}) (json.RawMessage, error) {
+	if emptyMetadata(md) {
+		return nil, fmt.Errorf("metadata_error: %w", errNoMetadata)
+	}
+
+	switch v := md.(type) {
+	case json.RawMessage:
+		return v, nil
+	case []byte:
+		return v, nil
+	}
+
+	b, err := json.Marshal(md)
+	if err != nil {
+		return nil, fmt.Errorf("metadata_error: %w", err)
+	}
+
+	return b, nil
+}
+
+func emptyMetadata(md interface{}) bool {
+	switch v := md.(type) {
+	case nil:
+		return true
+	case json.RawMessage:
+		return len(v) == 0 || string(v) == "null"
+	case map[string]interface{}:
+		return v == nil
+	}
+
+	return false
+}
+
+// setMetadataObject decodes b into the map used by the resources only
+// accepting objects as metadata.
+func setMetadataObject(dst *map[string]interface{}, b json.RawMessage) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil || m == nil {
+		return fmt.Errorf("metadata_error: %w", errMetadataObject)
+	}
+
+	*dst = m
+
+	return nil
+}
+
+func (p *Payment) metadata() interface{} { return p.Metadata }
+
+func (p *Payment) setMetadata(b json.RawMessage) error {
+	p.Metadata = b
+
+	return nil
+}
+
+func (o *Order) metadata() interface{} { return o.Metadata }
+
+func (o *Order) setMetadata(b json.RawMessage) error {
+	o.Metadata = b
+
+	return nil
+}
+
+func (ol *OrderLine) metadata() interface{} { return ol.Metadata }
+
+func (ol *OrderLine) setMetadata(b json.RawMessage) error {
+	ol.Metadata = b
+
+	return nil
+}
+
+func (r *Refund) metadata() interface{} { return r.Metadata }
+
+func (r *Refund) setMetadata(b json.RawMessage) error {
+	r.Metadata = b
+
+	return nil
+}
+
+func (c *Customer) metadata() interface{} { return c.Metadata }
+
+func (c *Customer) setMetadata(b json.RawMessage) error {
+	return setMetadataObject(&c.Metadata, b)
+}
+
+func (s *Subscription) metadata() interface{} { return s.Metadata }
+
+func (s *Subscription) setMetadata(b json.RawMessage) error {
+	return setMetadataObject(&s.Metadata, b)
+}
@@ -0,0 +1,112 @@
+package mollie
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderRef struct {
+	ID      string `json:"id"`
+	Channel string `json:"channel,omitempty"`
+}
+
+func TestSetMetadata(t *testing.T) {
+	ref := orderRef{ID: "1234", Channel: "web"}
+
+	holders := []MetadataHolder{&Payment{}, &Order{}, &OrderLine{}, &Refund{}, &Customer{}, &Subscription{}}
+
+	for _, h := range holders {
+		require.Nil(t, SetMetadata(h, ref))
+
+		got, err := DecodeMetadata[orderRef](h.metadata())
+		require.Nil(t, err)
+		assert.Equal(t, ref, got)
+	}
+
+	p := Payment{}
+	require.Nil(t, SetMetadata(&p, ref))
+
+	b, err := json.Marshal(p)
+	require.Nil(t, err)
+	assert.Contains(t, string(b), `"metadata":{"id":"1234","channel":"web"}`)
+
+	c := Customer{}
+	require.Nil(t, SetMetadata(&c, ref))
+	assert.Equal(t, map[string]interface{}{"id": "1234", "channel": "web"}, c.Metadata)
+}
+
+func TestSetMetadata_Errors(t *testing.T) {
+	p := Payment{}
+
+	err := SetMetadata(&p, strings.Repeat("a", MaxMetadataSize))
+	assert.ErrorIs(t, err, errMetadataTooLarge)
+	assert.EqualError(t, err, "metadata_error: 1026 bytes: metadata exceeds the limit of 1024 bytes")
+	assert.Nil(t, p.Metadata)
+
+	assert.Nil(t, SetMetadata(&p, "order 1234"))
+	assert.ErrorIs(t, SetMetadata(&Customer{}, "order 1234"), errMetadataObject)
+	assert.ErrorIs(t, SetMetadata(&Subscription{}, []string{"a"}), errMetadataObject)
+
+	assert.NotNil(t, SetMetadata(&p, func() {}))
+}
+
+func TestDecodeMetadata(t *testing.T) {
+	var p Payment
+	require.Nil(t, json.Unmarshal([]byte(`{"metadata": {"id": "1234", "channel": "pos"}}`), &p))
+
+	ref, err := DecodeMetadata[orderRef](p.Metadata)
+	require.Nil(t, err)
+	assert.Equal(t, orderRef{ID: "1234", Channel: "pos"}, ref)
+
+	s, err := DecodeMetadata[string](json.RawMessage(`"order 1234"`))
+	require.Nil(t, err)
+	assert.Equal(t, "order 1234", s)
+
+	_, err = DecodeMetadata[orderRef](nil)
+	assert.ErrorIs(t, err, errNoMetadata)
+
+	_, err = DecodeMetadata[orderRef](json.RawMessage(`null`))
+	assert.ErrorIs(t, err, errNoMetadata)
+
+	_, err = DecodeMetadata[orderRef]("order 1234")
+	assert.NotNil(t, err)
+}
+
+func TestCorrelationID(t *testing.T) {
+	o := Order{}
+	require.Nil(t, SetCorrelationID(&o, "inv-2023-001"))
+	assert.Equal(t, "inv-2023-001", CorrelationID(o.Metadata))
+
+	c := Customer{Metadata: map[string]interface{}{"segment": "b2b"}}
+	require.Nil(t, SetCorrelationID(&c, "crm-42"))
+	assert.Equal(t, map[string]interface{}{"segment": "b2b", CorrelationIDKey: "crm-42"}, c.Metadata)
+
+	p := Payment{}
+	require.Nil(t, SetMetadata(&p, orderRef{ID: "1234"}))
+	require.Nil(t, SetCorrelationID(&p, "inv-2023-002"))
+
+	ref, err := DecodeMetadata[orderRef](p.Metadata)
+	require.Nil(t, err)
+	assert.Equal(t, "1234", ref.ID)
+	assert.Equal(t, "inv-2023-002", CorrelationID(p.Metadata))
+
+	p.Metadata = "order 1234"
+	assert.ErrorIs(t, SetCorrelationID(&p, "inv-2023-003"), errMetadataObject)
+	assert.Equal(t, "", CorrelationID(p.Metadata))
+	assert.Equal(t, "", CorrelationID(nil))
+}
+
+func TestValidate_Metadata(t *testing.T) {
+	p := Payment{Metadata: map[string]string{"note": strings.Repeat("a", MaxMetadataSize)}}
+	assert.Equal(t, []string{"metadata"}, fieldsOf(t, p.Validate()))
+
+	o := Order{Lines: []*OrderLine{{Name: "LEGO", Metadata: json.RawMessage(`{"sku":`)}}}
+	assert.Equal(t, []string{"lines.0.metadata"}, fieldsOf(t, o.Validate()))
+
+	c := Customer{Metadata: map[string]interface{}{"id": "1234"}}
+	assert.Nil(t, c.Validate())
+}
@@ -0,0 +1,299 @@
+// Package methods resolves the payment methods available to a checkout,
+// caching the responses of the methods API.
+//
+// The enabled methods rarely change, so a Resolver keeps every list it
+// requested for a while instead of asking the API on each page render.
+// Invalidate the cache after enabling or disabling methods:
+//
+//	r := methods.NewResolver(client, &methods.Options{TTL: 10 * time.Minute})
+//
+//	available, err := r.ForAmount(ctx, &mollie.Amount{Currency: "EUR", Value: "24.95"}, nil)
+//	banks, err := r.Issuers(ctx, mollie.IDeal, nil)
+//
+//	_, _, err = client.Profiles.EnablePaymentMethod(ctx, "pfl_v9hTwCvYqw", mollie.Bancontact)
+//	r.InvalidateProfile("pfl_v9hTwCvYqw")
+package methods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/google/go-querystring/query"
+)
+
+// DefaultTTL is the time a list of methods is cached for by default.
+const DefaultTTL = 5 * time.Minute
+
+var (
+	errMethodUnavailable = errors.New("the payment method is not available")
+	errNoAmount          = errors.New("an amount is required")
+)
+
+// Options configures a resolver.
+type Options struct {
+	// TTL is the time a list of methods is cached for.
+	TTL time.Duration
+}
+
+// Resolver caches the payment methods lists per endpoint and options.
+//
+// Concurrent requests for the same list share a single API request. The
+// returned methods are shared between callers and must not be modified.
+type Resolver struct {
+	client  *mollie.Client
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	profileID string
+	ready     chan struct{}
+	methods   []*mollie.PaymentMethodDetails
+	err       error
+	expires   time.Time
+	// abandoned is set when the lookup failed because the context of
+	// its caller ended.
+	abandoned bool
+}
+
+func (e *entry) done() bool {
+	select {
+	case <-e.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+type listFunc func(ctx context.Context, opts *mollie.PaymentMethodsListOptions) (*mollie.Response, *mollie.PaymentMethodsList, error)
+
+// NewResolver creates a resolver using the given client, a nil options
+// value uses the defaults.
+func NewResolver(client *mollie.Client, opts *Options) *Resolver {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.TTL <= 0 {
+		o.TTL = DefaultTTL
+	}
+
+	return &Resolver{
+		client:  client,
+		ttl:     o.TTL,
+		now:     time.Now,
+		entries: map[string]*entry{},
+	}
+}
+
+// List returns the methods enabled for the options, as returned by
+// PaymentMethodsService.List.
+func (r *Resolver) List(ctx context.Context, opts *mollie.PaymentMethodsListOptions) ([]*mollie.PaymentMethodDetails, error) {
+	return r.resolve(ctx, "methods", opts, r.client.PaymentMethods.List)
+}
+
+// All returns all the methods of the account, as returned by
+// PaymentMethodsService.All.
+func (r *Resolver) All(ctx context.Context, opts *mollie.PaymentMethodsListOptions) ([]*mollie.PaymentMethodDetails, error) {
+	return r.resolve(ctx, "all", opts, r.client.PaymentMethods.All)
+}
+
+// ForAmount returns the enabled methods accepting a payment of amount.
+//
+// When the limits of the methods are expressed in the currency of the
+// amount, the cached list for the options is filtered using their minimum
+// and maximum amounts. Otherwise the amount is sent to the API, which
+// converts the limits, and the result is cached for that amount.
+func (r *Resolver) ForAmount(ctx context.Context, amount *mollie.Amount, opts *mollie.PaymentMethodsListOptions) ([]*mollie.PaymentMethodDetails, error) {
+	if amount == nil {
+		return nil, fmt.Errorf("methods_error: %w", errNoAmount)
+	}
+
+	o := mollie.PaymentMethodsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	o.AmountCurrency, o.AmountValue = "", ""
+
+	methods, err := r.List(ctx, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	if limitsIn(methods, amount.Currency) {
+		return AcceptingAmount(methods, amount)
+	}
+
+	o.AmountCurrency, o.AmountValue = amount.Currency, amount.Value
+
+	return r.List(ctx, &o)
+}
+
+// Issuers returns the issuers of an enabled method, such as the banks of
+// iDEAL and KBC/CBC or the gift card brands.
+func (r *Resolver) Issuers(ctx context.Context, method mollie.PaymentMethod, opts *mollie.PaymentMethodsListOptions) ([]*mollie.PaymentMethodIssuer, error) {
+	o := mollie.PaymentMethodsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	o.Include = o.Include.With(mollie.IncludeIssuers)
+
+	methods, err := r.List(ctx, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range methods {
+		if m.ID == string(method) {
+			return m.Issuers, nil
+		}
+	}
+
+	return nil, fmt.Errorf("methods_error: %s: %w", method, errMethodUnavailable)
+}
+
+// Invalidate drops all the cached lists.
+func (r *Resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = map[string]*entry{}
+}
+
+// InvalidateProfile drops the lists cached for a profile, including the
+// ones requested without profile ID as they belong to the profile of the
+// API key.
+func (r *Resolver) InvalidateProfile(profileID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, e := range r.entries {
+		if e.profileID == profileID || e.profileID == "" {
+			delete(r.entries, k)
+		}
+	}
+}
+
+func (r *Resolver) resolve(ctx context.Context, endpoint string, opts *mollie.PaymentMethodsListOptions, list listFunc) ([]*mollie.PaymentMethodDetails, error) {
+	o := mollie.PaymentMethodsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, fmt.Errorf("methods_error: %w", err)
+	}
+
+	key := endpoint + "?" + v.Encode()
+
+	for {
+		r.mu.Lock()
+
+		e, ok := r.entries[key]
+		if !ok || (e.done() && !r.now().Before(e.expires)) {
+			break
+		}
+
+		r.mu.Unlock()
+
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// a lookup abandoned by its caller is retried with this context.
+		if !e.abandoned {
+			return e.methods, e.err
+		}
+	}
+
+	e := &entry{profileID: o.ProfileID, ready: make(chan struct{})}
+	r.entries[key] = e
+	r.mu.Unlock()
+
+	_, pl, err := list(ctx, &o)
+
+	r.mu.Lock()
+	if err != nil {
+		e.err = err
+		e.abandoned = ctx.Err() != nil
+
+		// failures are not cached.
+		if r.entries[key] == e {
+			delete(r.entries, key)
+		}
+	} else {
+		e.methods = pl.Embedded.Methods
+		e.expires = r.now().Add(r.ttl)
+	}
+	r.mu.Unlock()
+
+	close(e.ready)
+
+	return e.methods, e.err
+}
+
+// AcceptingAmount returns the methods whose minimum and maximum amounts
+// allow amount. Limits in another currency can't be compared, methods
+// using them are left out.
+func AcceptingAmount(methods []*mollie.PaymentMethodDetails, amount *mollie.Amount) ([]*mollie.PaymentMethodDetails, error) {
+	if amount == nil {
+		return nil, fmt.Errorf("methods_error: %w", errNoAmount)
+	}
+
+	v, err := amount.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("methods_error: %w", err)
+	}
+
+	var accepted []*mollie.PaymentMethodDetails
+
+	for _, m := range methods {
+		if !limitsIn([]*mollie.PaymentMethodDetails{m}, amount.Currency) {
+			continue
+		}
+
+		if m.MinimumAmount != nil {
+			min, err := m.MinimumAmount.Rat()
+			if err != nil || v.Cmp(min) < 0 {
+				continue
+			}
+		}
+
+		if m.MaximumAmount != nil {
+			max, err := m.MaximumAmount.Rat()
+			if err != nil || v.Cmp(max) > 0 {
+				continue
+			}
+		}
+
+		accepted = append(accepted, m)
+	}
+
+	return accepted, nil
+}
+
+// limitsIn reports whether all the limits of the methods are expressed
+// in currency.
+func limitsIn(methods []*mollie.PaymentMethodDetails, currency string) bool {
+	for _, m := range methods {
+		for _, a := range []*mollie.Amount{m.MinimumAmount, m.MaximumAmount} {
+			if a != nil && a.Currency != currency {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,325 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const methodsList = `{
+    "count": 3,
+    "_embedded": {"methods": [
+        {
+            "resource": "method", "id": "ideal", "description": "iDEAL",
+            "minimumAmount": {"value": "0.01", "currency": "EUR"},
+            "maximumAmount": {"value": "50000.00", "currency": "EUR"},
+            "issuers": [
+                {"resource": "issuer", "id": "ideal_ABNANL2A", "name": "ABN AMRO"},
+                {"resource": "issuer", "id": "ideal_ASNBNL21", "name": "ASN Bank"}
+            ]
+        },
+        {
+            "resource": "method", "id": "creditcard", "description": "Credit card",
+            "minimumAmount": {"value": "0.01", "currency": "EUR"},
+            "maximumAmount": {"value": "2000.00", "currency": "EUR"}
+        },
+        {
+            "resource": "method", "id": "banktransfer", "description": "Bank transfer",
+            "minimumAmount": {"value": "1.00", "currency": "EUR"},
+            "maximumAmount": null
+        }
+    ]},
+    "_links": {}
+}`
+
+const usdList = `{
+    "count": 1,
+    "_embedded": {"methods": [
+        {"resource": "method", "id": "paypal", "description": "PayPal"}
+    ]},
+    "_links": {}
+}`
+
+type server struct {
+	mu       sync.Mutex
+	requests []*url.URL
+	fail     bool
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL)
+	fail := s.fail
+	s.mu.Unlock()
+
+	if fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"status": 500, "title": "Internal Server Error", "detail": "down"}`))
+
+		return
+	}
+
+	if r.URL.Query().Get("amount[currency]") == "USD" {
+		_, _ = w.Write([]byte(usdList))
+
+		return
+	}
+
+	_, _ = w.Write([]byte(methodsList))
+}
+
+func (s *server) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.requests)
+}
+
+func setup(t *testing.T, opts *Options) (*Resolver, *server) {
+	t.Helper()
+
+	s := &server{}
+
+	return NewResolver(mollietest.NewClient(t, s), opts), s
+}
+
+func TestResolver_List(t *testing.T) {
+	r, s := setup(t, &Options{TTL: time.Minute})
+
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	methods, err := r.List(ctx, &mollie.PaymentMethodsListOptions{Resource: "payments"})
+	require.Nil(t, err)
+	assert.Len(t, methods, 3)
+
+	_, err = r.List(ctx, &mollie.PaymentMethodsListOptions{Resource: "payments"})
+	require.Nil(t, err)
+	assert.Equal(t, 1, s.count())
+
+	_, err = r.List(ctx, &mollie.PaymentMethodsListOptions{Resource: "orders"})
+	require.Nil(t, err)
+	assert.Equal(t, 2, s.count())
+
+	_, err = r.All(ctx, &mollie.PaymentMethodsListOptions{Resource: "payments"})
+	require.Nil(t, err)
+	assert.Equal(t, 3, s.count())
+	assert.Equal(t, "/v2/methods/all", s.requests[2].Path)
+
+	now = now.Add(time.Minute)
+
+	_, err = r.List(ctx, &mollie.PaymentMethodsListOptions{Resource: "payments"})
+	require.Nil(t, err)
+	assert.Equal(t, 4, s.count())
+}
+
+func TestResolver_ListConcurrent(t *testing.T) {
+	r, s := setup(t, nil)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			methods, err := r.List(context.Background(), nil)
+			assert.Nil(t, err)
+			assert.Len(t, methods, 3)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, s.count())
+}
+
+func TestResolver_ListErrorsAreNotCached(t *testing.T) {
+	r, s := setup(t, nil)
+
+	s.fail = true
+
+	_, err := r.List(context.Background(), nil)
+	require.NotNil(t, err)
+
+	var base *mollie.BaseError
+	assert.True(t, errors.As(err, &base))
+
+	s.fail = false
+
+	methods, err := r.List(context.Background(), nil)
+	require.Nil(t, err)
+	assert.Len(t, methods, 3)
+	assert.Equal(t, 2, s.count())
+}
+
+func TestResolver_ListCanceledLookup(t *testing.T) {
+	r, _ := setup(t, nil)
+
+	var pl *mollie.PaymentMethodsList
+	require.Nil(t, json.Unmarshal([]byte(methodsList), &pl))
+
+	started := make(chan struct{})
+	calls := 0
+
+	list := func(ctx context.Context, opts *mollie.PaymentMethodsListOptions) (*mollie.Response, *mollie.PaymentMethodsList, error) {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-ctx.Done()
+
+			return nil, nil, ctx.Err()
+		}
+
+		return nil, pl, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+
+	go func() {
+		_, err := r.resolve(ctx, "methods", nil, list)
+		canceled <- err
+	}()
+
+	<-started
+
+	waiting := make(chan error)
+
+	go func() {
+		_, err := r.resolve(context.Background(), "methods", nil, list)
+		waiting <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	assert.True(t, errors.Is(<-canceled, context.Canceled))
+	assert.Nil(t, <-waiting)
+	assert.Equal(t, 2, calls)
+}
+
+func TestResolver_Invalidate(t *testing.T) {
+	r, s := setup(t, nil)
+	ctx := context.Background()
+
+	profile := &mollie.PaymentMethodsListOptions{
+		PaymentMethodOptions: mollie.PaymentMethodOptions{ProfileID: "pfl_v9hTwCvYqw"},
+	}
+	other := &mollie.PaymentMethodsListOptions{
+		PaymentMethodOptions: mollie.PaymentMethodOptions{ProfileID: "pfl_QkEhN94Ba"},
+	}
+
+	for _, o := range []*mollie.PaymentMethodsListOptions{nil, profile, other} {
+		_, err := r.List(ctx, o)
+		require.Nil(t, err)
+	}
+
+	require.Equal(t, 3, s.count())
+
+	r.InvalidateProfile("pfl_v9hTwCvYqw")
+
+	for _, o := range []*mollie.PaymentMethodsListOptions{nil, profile, other} {
+		_, err := r.List(ctx, o)
+		require.Nil(t, err)
+	}
+
+	assert.Equal(t, 5, s.count())
+
+	r.Invalidate()
+
+	_, err := r.List(ctx, other)
+	require.Nil(t, err)
+	assert.Equal(t, 6, s.count())
+}
+
+func TestResolver_ForAmount(t *testing.T) {
+	r, s := setup(t, nil)
+	ctx := context.Background()
+
+	ids := func(methods []*mollie.PaymentMethodDetails) (res []string) {
+		for _, m := range methods {
+			res = append(res, m.ID)
+		}
+
+		return
+	}
+
+	cases := []struct {
+		value string
+		want  []string
+	}{
+		{"0.50", []string{"ideal", "creditcard"}},
+		{"24.95", []string{"ideal", "creditcard", "banktransfer"}},
+		{"2500.00", []string{"ideal", "banktransfer"}},
+		{"60000.00", []string{"banktransfer"}},
+	}
+
+	for _, c := range cases {
+		methods, err := r.ForAmount(ctx, &mollie.Amount{Currency: "EUR", Value: c.value}, nil)
+		require.Nil(t, err)
+		assert.Equal(t, c.want, ids(methods), c.value)
+	}
+
+	assert.Equal(t, 1, s.count())
+	assert.Empty(t, s.requests[0].Query().Get("amount[value]"))
+
+	methods, err := r.ForAmount(ctx, &mollie.Amount{Currency: "USD", Value: "10.00"}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"paypal"}, ids(methods))
+	assert.Equal(t, 2, s.count())
+	assert.Equal(t, "10.00", s.requests[1].Query().Get("amount[value]"))
+
+	_, err = r.ForAmount(ctx, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestResolver_Issuers(t *testing.T) {
+	r, s := setup(t, nil)
+	ctx := context.Background()
+
+	issuers, err := r.Issuers(ctx, mollie.IDeal, nil)
+	require.Nil(t, err)
+	require.Len(t, issuers, 2)
+	assert.Equal(t, "ideal_ABNANL2A", issuers[0].ID)
+	assert.Equal(t, "issuers", s.requests[0].Query().Get("include"))
+
+	issuers, err = r.Issuers(ctx, mollie.CreditCard, &mollie.PaymentMethodsListOptions{
+		PaymentMethodOptions: mollie.PaymentMethodOptions{Include: mollie.MethodIncludes{mollie.IncludePricing}},
+	})
+	require.Nil(t, err)
+	assert.Empty(t, issuers)
+	assert.Equal(t, "pricing,issuers", s.requests[1].Query().Get("include"))
+
+	_, err = r.Issuers(ctx, mollie.KBC, nil)
+	assert.True(t, errors.Is(err, errMethodUnavailable))
+	assert.Equal(t, 2, s.count())
+}
+
+func TestAcceptingAmount(t *testing.T) {
+	methods := []*mollie.PaymentMethodDetails{
+		{ID: "ideal", MinimumAmount: &mollie.Amount{Currency: "EUR", Value: "0.01"}},
+		{ID: "voucher", MinimumAmount: &mollie.Amount{Currency: "GBP", Value: "1.00"}},
+	}
+
+	accepted, err := AcceptingAmount(methods, &mollie.Amount{Currency: "EUR", Value: "5.00"})
+	require.Nil(t, err)
+	require.Len(t, accepted, 1)
+	assert.Equal(t, "ideal", accepted[0].ID)
+
+	_, err = AcceptingAmount(methods, &mollie.Amount{Currency: "EUR", Value: "five"})
+	assert.NotNil(t, err)
+}
@@ -15,7 +15,7 @@ type ApplePaymentSession struct {
 	ExpiresAt         int    `json:"expiresAt,omitempty"`
 	MerchantSessionID string `json:"merchantSessionIdentifier,omitempty"`
 	Nonce             string `json:"nonce,omitempty"`
-	MerchantID        string `json:"merchantIdentified,omitempty"`
+	MerchantID        string `json:"merchantIdentifier,omitempty"`
 	DomainName        string `json:"domainName,omitempty"`
 	DisplayName       string `json:"displayName,omitempty"`
 	Signature         string `json:"signature,omitempty"`
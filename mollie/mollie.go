@@ -25,12 +25,14 @@ const (
 	APITokenEnv        string = "MOLLIE_API_TOKEN"
 	OrgTokenEnv        string = "MOLLIE_ORG_TOKEN"
 	RequestContentType string = "application/json"
+	IdempotencyHeader  string = "Idempotency-Key"
 )
 
 var (
 	accessTokenExpr = regexp.MustCompile(`(?m)^access_`)
 	errEmptyAuthKey = errors.New("you must provide a non-empty authentication key")
 	errBadBaseURL   = errors.New("malformed base url, it must contain a trailing slash")
+	errEmptyLink    = errors.New("the link to follow is empty")
 )
 
 // Client manages communication with Mollie's API.
@@ -61,19 +63,27 @@ type Client struct {
 	Onboarding     *OnboardingService
 	PaymentLinks   *PaymentLinksService
 	Partners       *PartnerService
+	ClientLinks    *ClientLinksService
+	Terminals      *TerminalsService
 }
 
 type service struct {
 	client *Client
 }
 
-func (c *Client) get(ctx context.Context, uri string, options interface{}) (res *Response, err error) {
-	if options != nil {
-		v, _ := query.Values(options)
-		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
-	}
+type idempotencyKey struct{}
 
-	req, err := c.NewAPIRequest(ctx, http.MethodGet, uri, nil)
+// WithIdempotencyKey returns a context that makes the requests sent with
+// it carry key in the Idempotency-Key header, so a retried request creating
+// or updating a resource is only executed once by Mollie.
+//
+// The header is not sent on GET requests.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func (c *Client) get(ctx context.Context, uri string, options interface{}) (res *Response, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, uri, nil, options)
 	if err != nil {
 		return
 	}
@@ -81,13 +91,19 @@ func (c *Client) get(ctx context.Context, uri string, options interface{}) (res
 	return c.Do(req)
 }
 
-func (c *Client) post(ctx context.Context, uri string, body interface{}, options interface{}) (res *Response, err error) {
-	if options != nil {
-		v, _ := query.Values(options)
-		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+// stream sends a GET request like get, but the body of a successful
+// response is left unread for the caller to decode and close.
+func (c *Client) stream(ctx context.Context, uri string, options interface{}) (res *Response, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, uri, nil, options)
+	if err != nil {
+		return
 	}
 
-	req, err := c.NewAPIRequest(ctx, http.MethodPost, uri, body)
+	return c.open(req)
+}
+
+func (c *Client) post(ctx context.Context, uri string, body interface{}, options interface{}) (res *Response, err error) {
+	req, err := c.newRequest(ctx, http.MethodPost, uri, body, options)
 	if err != nil {
 		return
 	}
@@ -96,12 +112,16 @@ func (c *Client) post(ctx context.Context, uri string, body interface{}, options
 }
 
 func (c *Client) patch(ctx context.Context, uri string, body interface{}, options interface{}) (res *Response, err error) {
-	if options != nil {
-		v, _ := query.Values(options)
-		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+	req, err := c.newRequest(ctx, http.MethodPatch, uri, body, options)
+	if err != nil {
+		return
 	}
 
-	req, err := c.NewAPIRequest(ctx, http.MethodPatch, uri, body)
+	return c.Do(req)
+}
+
+func (c *Client) delete(ctx context.Context, uri string, options interface{}) (res *Response, err error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, uri, nil, options)
 	if err != nil {
 		return
 	}
@@ -109,13 +129,26 @@ func (c *Client) patch(ctx context.Context, uri string, body interface{}, option
 	return c.Do(req)
 }
 
-func (c *Client) delete(ctx context.Context, uri string, options interface{}) (res *Response, err error) {
-	if options != nil {
-		v, _ := query.Values(options)
-		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+// follow sends a GET request to the href of a HAL link.
+//
+// Only the path and query of the link are used, they are resolved
+// against the client base url so requests always reach the configured API.
+func (c *Client) follow(ctx context.Context, link *URL) (res *Response, err error) {
+	if link == nil || link.Href == "" {
+		return nil, errEmptyLink
 	}
 
-	req, err := c.NewAPIRequest(ctx, http.MethodDelete, uri, nil)
+	u, err := url.Parse(link.Href)
+	if err != nil {
+		return nil, fmt.Errorf("url_parsing_error: %w", err)
+	}
+
+	uri := strings.TrimPrefix(u.Path, "/")
+	if u.RawQuery != "" {
+		uri = fmt.Sprintf("%s?%s", uri, u.RawQuery)
+	}
+
+	req, err := c.NewAPIRequest(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return
 	}
@@ -123,6 +156,103 @@ func (c *Client) delete(ctx context.Context, uri string, options interface{}) (r
 	return c.Do(req)
 }
 
+// download streams the body of the document behind a link to w.
+//
+// Documents such as invoice PDFs are served outside the API, so the
+// link is requested as is and the authorization header is only sent
+// when the link points to the API host. Error responses are buffered
+// and checked like any other response, successful ones are copied to
+// w without being kept in memory.
+func (c *Client) download(ctx context.Context, link *URL, w io.Writer) (res *Response, err error) {
+	if link == nil || link.Href == "" {
+		return nil, errEmptyLink
+	}
+
+	u, err := c.BaseURL.Parse(link.Href)
+	if err != nil {
+		return nil, fmt.Errorf("url_parsing_error: %w", err)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return
+	}
+
+	if u.Host == c.BaseURL.Host {
+		req.Header.Add(AuthHeader, strings.Join([]string{TokenType, c.authentication}, " "))
+	}
+
+	if link.Type != "" {
+		req.Header.Set("Accept", link.Type)
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+
+	res, err = c.open(req)
+	if err != nil {
+		return
+	}
+
+	defer res.Body.Close()
+
+	if _, err = io.Copy(w, res.Body); err != nil {
+		return res, fmt.Errorf("download_error: %w", err)
+	}
+
+	return
+}
+
+// open sends a request and returns the response without reading its body,
+// the caller must close it. Error responses are read, closed and checked
+// like in Do.
+func (c *Client) open(req *http.Request) (*Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("httperror: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+
+		response, err := newResponse(resp)
+		if err != nil {
+			return response, err
+		}
+
+		return response, CheckResponse(response)
+	}
+
+	return &Response{Response: resp}, nil
+}
+
+// newRequest creates an API request for uri with the encoded options.
+func (c *Client) newRequest(ctx context.Context, method, uri string, body interface{}, options interface{}) (*http.Request, error) {
+	u, err := withQuery(uri, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewAPIRequest(ctx, method, u, body)
+}
+
+// withQuery appends the encoded options to uri.
+func withQuery(uri string, options interface{}) (string, error) {
+	if options == nil {
+		return uri, nil
+	}
+
+	v, err := query.Values(options)
+	if err != nil {
+		return "", fmt.Errorf("query_error: %w", err)
+	}
+
+	return fmt.Sprintf("%s?%s", uri, v.Encode()), nil
+}
+
 // WithAuthenticationValue offers a convenient setter for any of the valid authentication
 // tokens provided by Mollie.
 //
@@ -193,6 +323,10 @@ func (c *Client) NewAPIRequest(ctx context.Context, method string, uri string, b
 	req.Header.Set("Accept", RequestContentType)
 	req.Header.Set("User-Agent", c.userAgent)
 
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" && method != http.MethodGet {
+		req.Header.Set(IdempotencyHeader, key)
+	}
+
 	return
 }
 
@@ -263,6 +397,8 @@ func NewClient(baseClient *http.Client, conf *Config) (mollie *Client, err error
 	mollie.Onboarding = (*OnboardingService)(&mollie.common)
 	mollie.PaymentLinks = (*PaymentLinksService)(&mollie.common)
 	mollie.Partners = (*PartnerService)(&mollie.common)
+	mollie.ClientLinks = (*ClientLinksService)(&mollie.common)
+	mollie.Terminals = (*TerminalsService)(&mollie.common)
 
 	mollie.userAgent = strings.Join([]string{
 		runtime.GOOS,
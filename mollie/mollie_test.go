@@ -282,6 +282,22 @@ func TestClient_NewAPIRequest_OrgTokenOverApiKey(t *testing.T) {
 	testHeader(t, req, AuthHeader, "Bearer org_token")
 }
 
+func TestClient_NewAPIRequest_IdempotencyKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx := WithIdempotencyKey(context.Background(), "refund-tr_WDqYK6vllg")
+
+	req, _ := tClient.NewAPIRequest(ctx, http.MethodPost, "v2/payments/tr_WDqYK6vllg/refunds", nil)
+	testHeader(t, req, IdempotencyHeader, "refund-tr_WDqYK6vllg")
+
+	req, _ = tClient.NewAPIRequest(ctx, http.MethodGet, "v2/payments/tr_WDqYK6vllg/refunds", nil)
+	testHeader(t, req, IdempotencyHeader, "")
+
+	req, _ = tClient.NewAPIRequest(context.Background(), http.MethodPost, "v2/payments/tr_WDqYK6vllg/refunds", nil)
+	testHeader(t, req, IdempotencyHeader, "")
+}
+
 func TestClient_WithAuthenticationValue_Error(t *testing.T) {
 	setup()
 	defer teardown()
@@ -85,6 +85,10 @@ type OnboardingData struct {
 //
 // See: https://docs.mollie.com/reference/v2/onboarding-api/submit-onboarding-data
 func (os *OnboardingService) SubmitOnboardingData(ctx context.Context, d *OnboardingData) (res *Response, err error) {
+	if err = os.client.validate(d); err != nil {
+		return
+	}
+
 	res, err = os.client.post(ctx, onboardingTarget, d, nil)
 	if err != nil {
 		return
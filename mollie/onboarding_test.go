@@ -85,6 +85,9 @@ func (os *onboardingServiceSuite) TestOnboardingService_GetOnboardingStatus() {
 }
 
 func (os *onboardingServiceSuite) TestOnboardingService_SubmitOnboardingData() {
+	data := &OnboardingData{}
+	data.Organization.Name = "Mollie B.V."
+
 	cases := []struct {
 		name    string
 		data    *OnboardingData
@@ -95,7 +98,7 @@ func (os *onboardingServiceSuite) TestOnboardingService_SubmitOnboardingData() {
 	}{
 		{
 			"get onboarding status works as expected.",
-			&OnboardingData{},
+			data,
 			false,
 			nil,
 			noPre,
@@ -112,16 +115,24 @@ func (os *onboardingServiceSuite) TestOnboardingService_SubmitOnboardingData() {
 		},
 		{
 			"get onboarding status, an error is returned from the server",
-			&OnboardingData{},
+			data,
 			true,
 			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
 			noPre,
 			errorHandler,
 		},
 		{
-			"get onboarding status, invalid url when building request",
+			"submit onboarding data, empty data is rejected before sending",
 			&OnboardingData{},
 			true,
+			fmt.Errorf("validation_error: 422 Unprocessable Entity: The onboarding data must contain at least one field."),
+			noPre,
+			errorHandler,
+		},
+		{
+			"get onboarding status, invalid url when building request",
+			data,
+			true,
 			errBadBaseURL,
 			crashSrv,
 			errorHandler,
@@ -0,0 +1,351 @@
+package mollie
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	errOrderNoLines      = errors.New("the order has no lines")
+	errOrderNoCurrency   = errors.New("the order has no currency")
+	errOrderLineName     = errors.New("the line has no name")
+	errOrderLineQuantity = errors.New("quantity must be positive")
+	errOrderLineAmount   = errors.New("amounts must be decimal numbers using the decimals of the currency")
+	errOrderLineSign     = errors.New("discount, store credit and gift card lines must have a negative unit price, other lines a positive one")
+	errOrderLineDiscount = errors.New("discount amount must be positive and can't exceed the line amount")
+	errOrderLineVatRate  = errors.New("vat rate must be a decimal number between 0 and 100")
+	errOrderLineTotal    = errors.New("total amount must equal unit price × quantity − discount amount")
+	errOrderLineVat      = errors.New("vat amount must equal total amount × (vat rate / (100 + vat rate))")
+	errOrderAmount       = errors.New("the order amount must equal the sum of the line totals")
+	errOrderCurrency     = errors.New("all the amounts must use the order currency")
+)
+
+// deductions are the product types lowering the order amount, their
+// lines have a negative unit price.
+var deductions = map[ProductType]bool{
+	Discount:        true,
+	StoreCredit:     true,
+	GiftCardProduct: true,
+}
+
+// OrderItem describes an order line added to an OrderBuilder. Amounts are
+// decimal values in the currency of the builder.
+type OrderItem struct {
+	Type     ProductType
+	Name     string
+	SKU      string
+	Quantity int
+	// UnitPrice includes VAT, it is negative for discount, store credit
+	// and gift card lines.
+	UnitPrice string
+	// Discount is the positive amount deducted from the line total.
+	Discount   string
+	VatRate    string
+	ImageURL   string
+	ProductURL string
+	Metadata   interface{}
+}
+
+// OrderBuilder computes the amounts of an order the way the Orders API
+// checks them: the total of every line, its VAT and the order amount,
+// rounding half away from zero to the decimals of the currency.
+//
+// Items are validated when the order is built, reporting the first
+// invalid line:
+//
+//	order, err := mollie.NewOrderBuilder("EUR").
+//		Product("LEGO 42083 Bugatti Chiron", 2, "399.00", "21.00").
+//		ShippingFee("Shipping", "4.99", "21.00").
+//		Discount("Coupon", "10.00", "21.00").
+//		Build(mollie.Order{OrderNumber: "1337", Locale: mollie.Dutch})
+type OrderBuilder struct {
+	currency string
+	items    []OrderItem
+}
+
+// NewOrderBuilder creates a builder for an order in currency.
+func NewOrderBuilder(currency string) *OrderBuilder {
+	return &OrderBuilder{currency: currency}
+}
+
+// Add adds items as they are.
+func (b *OrderBuilder) Add(items ...OrderItem) *OrderBuilder {
+	b.items = append(b.items, items...)
+
+	return b
+}
+
+// Product adds a physical product.
+func (b *OrderBuilder) Product(name string, quantity int, unitPrice, vatRate string) *OrderBuilder {
+	return b.Add(OrderItem{Type: Physical, Name: name, Quantity: quantity, UnitPrice: unitPrice, VatRate: vatRate})
+}
+
+// Digital adds a digital product.
+func (b *OrderBuilder) Digital(name string, quantity int, unitPrice, vatRate string) *OrderBuilder {
+	return b.Add(OrderItem{Type: Digital, Name: name, Quantity: quantity, UnitPrice: unitPrice, VatRate: vatRate})
+}
+
+// ShippingFee adds the shipping costs.
+func (b *OrderBuilder) ShippingFee(name, price, vatRate string) *OrderBuilder {
+	return b.Add(OrderItem{Type: ShippingFee, Name: name, Quantity: 1, UnitPrice: price, VatRate: vatRate})
+}
+
+// Surcharge adds a surcharge, like a payment fee.
+func (b *OrderBuilder) Surcharge(name, price, vatRate string) *OrderBuilder {
+	return b.Add(OrderItem{Type: Surcharge, Name: name, Quantity: 1, UnitPrice: price, VatRate: vatRate})
+}
+
+// Discount adds an order discount, amount is the positive value deducted
+// from the order amount.
+func (b *OrderBuilder) Discount(name, amount, vatRate string) *OrderBuilder {
+	return b.Add(OrderItem{Type: Discount, Name: name, Quantity: 1, UnitPrice: "-" + amount, VatRate: vatRate})
+}
+
+// Lines returns the order lines of the items with their computed totals
+// and VAT amounts.
+func (b *OrderBuilder) Lines() ([]*OrderLine, error) {
+	if b.currency == "" {
+		return nil, fmt.Errorf("order_error: %w", errOrderNoCurrency)
+	}
+
+	if len(b.items) == 0 {
+		return nil, fmt.Errorf("order_error: %w", errOrderNoLines)
+	}
+
+	lines := make([]*OrderLine, 0, len(b.items))
+
+	for i, item := range b.items {
+		l, err := b.line(item)
+		if err != nil {
+			return nil, fmt.Errorf("order_error: line %d (%s): %w", i, item.Name, err)
+		}
+
+		lines = append(lines, l)
+	}
+
+	return lines, nil
+}
+
+// Build returns a copy of o with the lines of the builder and their sum
+// as amount.
+func (b *OrderBuilder) Build(o Order) (*Order, error) {
+	lines, err := b.Lines()
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Rat)
+	for _, l := range lines {
+		v, _ := l.TotalAmount.Rat()
+		total.Add(total, v)
+	}
+
+	if total.Sign() < 0 {
+		return nil, fmt.Errorf("order_error: %w", errOrderAmount)
+	}
+
+	o.Lines = lines
+	o.Amount = AmountFromRat(b.currency, total)
+
+	if err := o.ValidateAmounts(); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (b *OrderBuilder) line(item OrderItem) (*OrderLine, error) {
+	if item.Name == "" {
+		return nil, errOrderLineName
+	}
+
+	if item.Quantity <= 0 {
+		return nil, errOrderLineQuantity
+	}
+
+	productType := item.Type
+	if productType == "" {
+		productType = Physical
+	}
+
+	unit, err := b.parse(item.UnitPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	if deductions[productType] != (unit.Sign() < 0) {
+		return nil, errOrderLineSign
+	}
+
+	discount := new(big.Rat)
+	if item.Discount != "" {
+		if discount, err = b.parse(item.Discount); err != nil {
+			return nil, err
+		}
+	}
+
+	total := new(big.Rat).Mul(unit, big.NewRat(int64(item.Quantity), 1))
+
+	if discount.Sign() < 0 || discount.Cmp(new(big.Rat).Abs(total)) > 0 {
+		return nil, errOrderLineDiscount
+	}
+
+	total.Sub(total, discount)
+
+	rate, err := vatRate(item.VatRate)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &OrderLine{
+		ProductType: productType,
+		Name:        item.Name,
+		SKU:         item.SKU,
+		Quantity:    item.Quantity,
+		UnitPrice:   AmountFromRat(b.currency, unit),
+		TotalAmount: AmountFromRat(b.currency, total),
+		VatRate:     rate.FloatString(2),
+		VatAmount:   AmountFromRat(b.currency, vatOf(total, rate)),
+		ImageURL:    item.ImageURL,
+		ProductURL:  item.ProductURL,
+		Metadata:    item.Metadata,
+	}
+
+	if discount.Sign() > 0 {
+		l.DiscountAmount = AmountFromRat(b.currency, discount)
+	}
+
+	return l, nil
+}
+
+// parse parses an amount of the builder currency, rejecting values with
+// more decimals than the currency uses.
+func (b *OrderBuilder) parse(value string) (*big.Rat, error) {
+	v, ok := new(big.Rat).SetString(value)
+	if !ok || !exact(b.currency, v) {
+		return nil, fmt.Errorf("%q: %w", value, errOrderLineAmount)
+	}
+
+	return v, nil
+}
+
+// ValidateAmounts checks the amounts of the order the way the Orders API
+// does: the total amount of every line must be its unit price times its
+// quantity minus its discount, the VAT amount must match the VAT rate
+// after rounding and the order amount must be the sum of the lines.
+func (o *Order) ValidateAmounts() error {
+	if err := o.checkAmounts(); err != nil {
+		return fmt.Errorf("order_error: %w", err)
+	}
+
+	return nil
+}
+
+// checkAmounts is ValidateAmounts without the error prefix, the request
+// validation uses its errors as field details.
+func (o *Order) checkAmounts() error {
+	if len(o.Lines) == 0 {
+		return errOrderNoLines
+	}
+
+	if o.Amount == nil || o.Amount.Currency == "" {
+		return errOrderNoCurrency
+	}
+
+	currency := o.Amount.Currency
+	sum := new(big.Rat)
+
+	for i, l := range o.Lines {
+		if err := l.validateAmounts(currency); err != nil {
+			return fmt.Errorf("line %d (%s): %w", i, l.Name, err)
+		}
+
+		v, _ := l.TotalAmount.Rat()
+		sum.Add(sum, v)
+	}
+
+	amount, err := o.Amount.Rat()
+	if err != nil || amount.Cmp(sum) != 0 {
+		return fmt.Errorf("%s, expected %s: %w", o.Amount.Value, AmountFromRat(currency, sum).Value, errOrderAmount)
+	}
+
+	return nil
+}
+
+func (l *OrderLine) validateAmounts(currency string) error {
+	if l.Quantity <= 0 {
+		return errOrderLineQuantity
+	}
+
+	if l.UnitPrice == nil || l.TotalAmount == nil || l.VatAmount == nil {
+		return errOrderLineAmount
+	}
+
+	values := map[*Amount]*big.Rat{}
+
+	for _, a := range []*Amount{l.UnitPrice, l.TotalAmount, l.VatAmount, l.DiscountAmount} {
+		if a == nil {
+			continue
+		}
+
+		if a.Currency != currency {
+			return fmt.Errorf("%s: %w", a.Currency, errOrderCurrency)
+		}
+
+		v, err := a.Rat()
+		if err != nil || !exact(currency, v) {
+			return fmt.Errorf("%q: %w", a.Value, errOrderLineAmount)
+		}
+
+		values[a] = v
+	}
+
+	total := new(big.Rat).Mul(values[l.UnitPrice], big.NewRat(int64(l.Quantity), 1))
+	if l.DiscountAmount != nil {
+		total.Sub(total, values[l.DiscountAmount])
+	}
+
+	if total.Cmp(values[l.TotalAmount]) != 0 {
+		return fmt.Errorf("%s, expected %s: %w", l.TotalAmount.Value, AmountFromRat(currency, total).Value, errOrderLineTotal)
+	}
+
+	rate, err := vatRate(l.VatRate)
+	if err != nil {
+		return err
+	}
+
+	vat, _ := AmountFromRat(currency, vatOf(total, rate)).Rat()
+	if vat.Cmp(values[l.VatAmount]) != 0 {
+		return fmt.Errorf("%s, expected %s: %w", l.VatAmount.Value, AmountFromRat(currency, vat).Value, errOrderLineVat)
+	}
+
+	return nil
+}
+
+// vatOf returns the VAT included in total, unrounded.
+func vatOf(total, rate *big.Rat) *big.Rat {
+	divisor := new(big.Rat).Add(big.NewRat(100, 1), rate)
+
+	return new(big.Rat).Mul(total, new(big.Rat).Quo(rate, divisor))
+}
+
+func vatRate(s string) (*big.Rat, error) {
+	if s == "" {
+		return new(big.Rat), nil
+	}
+
+	v, ok := new(big.Rat).SetString(s)
+	if !ok || v.Sign() < 0 || v.Cmp(big.NewRat(100, 1)) > 0 {
+		return nil, fmt.Errorf("%q: %w", s, errOrderLineVatRate)
+	}
+
+	return v, nil
+}
+
+// exact reports whether v has no more decimals than currency uses.
+func exact(currency string, v *big.Rat) bool {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currencyDecimals(currency))), nil)
+
+	return new(big.Rat).Mul(v, new(big.Rat).SetInt(scale)).IsInt()
+}
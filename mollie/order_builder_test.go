@@ -0,0 +1,141 @@
+package mollie
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBuilder_Build(t *testing.T) {
+	o, err := NewOrderBuilder("EUR").
+		Product("LEGO 42083 Bugatti Chiron", 2, "399.00", "21").
+		Add(OrderItem{Type: Digital, Name: "Manual", Quantity: 3, UnitPrice: "5.00", Discount: "2.50", VatRate: "9", SKU: "MAN-1"}).
+		ShippingFee("Shipping", "4.99", "21.00").
+		Surcharge("Payment fee", "0.29", "21").
+		Discount("Coupon", "10.00", "21").
+		Build(Order{OrderNumber: "1337"})
+	require.Nil(t, err)
+
+	assert.Equal(t, "1337", o.OrderNumber)
+	assert.Equal(t, &Amount{Currency: "EUR", Value: "805.78"}, o.Amount)
+	require.Len(t, o.Lines, 5)
+
+	type line struct {
+		kind                        ProductType
+		unit, discount, total, rate string
+		vat                         string
+	}
+
+	value := func(a *Amount) string {
+		if a == nil {
+			return ""
+		}
+
+		return a.Value
+	}
+
+	var got []line
+	for _, l := range o.Lines {
+		got = append(got, line{l.ProductType, value(l.UnitPrice), value(l.DiscountAmount), value(l.TotalAmount), l.VatRate, value(l.VatAmount)})
+	}
+
+	assert.Equal(t, []line{
+		{Physical, "399.00", "", "798.00", "21.00", "138.50"},
+		{Digital, "5.00", "2.50", "12.50", "9.00", "1.03"},
+		{ShippingFee, "4.99", "", "4.99", "21.00", "0.87"},
+		{Surcharge, "0.29", "", "0.29", "21.00", "0.05"},
+		{Discount, "-10.00", "", "-10.00", "21.00", "-1.74"},
+	}, got)
+
+	assert.Equal(t, "MAN-1", o.Lines[1].SKU)
+}
+
+func TestOrderBuilder_ZeroDecimalCurrency(t *testing.T) {
+	o, err := NewOrderBuilder("JPY").Product("Tea", 3, "1000", "10").Build(Order{})
+	require.Nil(t, err)
+
+	assert.Equal(t, "3000", o.Amount.Value)
+	assert.Equal(t, "273", o.Lines[0].VatAmount.Value)
+
+	_, err = NewOrderBuilder("JPY").Product("Tea", 1, "10.50", "10").Build(Order{})
+	assert.True(t, errors.Is(err, errOrderLineAmount))
+}
+
+func TestOrderBuilder_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		builder *OrderBuilder
+		want    error
+	}{
+		{"no currency", NewOrderBuilder("").Product("Tea", 1, "1.00", "21"), errOrderNoCurrency},
+		{"no lines", NewOrderBuilder("EUR"), errOrderNoLines},
+		{"no name", NewOrderBuilder("EUR").Product("", 1, "1.00", "21"), errOrderLineName},
+		{"no quantity", NewOrderBuilder("EUR").Product("Tea", 0, "1.00", "21"), errOrderLineQuantity},
+		{"invalid price", NewOrderBuilder("EUR").Product("Tea", 1, "one", "21"), errOrderLineAmount},
+		{"too many decimals", NewOrderBuilder("EUR").Product("Tea", 1, "1.005", "21"), errOrderLineAmount},
+		{"negative product", NewOrderBuilder("EUR").Product("Tea", 1, "-1.00", "21"), errOrderLineSign},
+		{"positive discount", NewOrderBuilder("EUR").Add(OrderItem{Type: Discount, Name: "Coupon", Quantity: 1, UnitPrice: "1.00"}), errOrderLineSign},
+		{"discount too large", NewOrderBuilder("EUR").Add(OrderItem{Name: "Tea", Quantity: 1, UnitPrice: "1.00", Discount: "2.00"}), errOrderLineDiscount},
+		{"negative discount", NewOrderBuilder("EUR").Add(OrderItem{Name: "Tea", Quantity: 1, UnitPrice: "1.00", Discount: "-0.50"}), errOrderLineDiscount},
+		{"vat rate", NewOrderBuilder("EUR").Product("Tea", 1, "1.00", "121"), errOrderLineVatRate},
+		{"negative order", NewOrderBuilder("EUR").Product("Tea", 1, "1.00", "21").Discount("Coupon", "5.00", "21"), errOrderAmount},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			o, err := c.builder.Build(Order{})
+			assert.Nil(t, o)
+			assert.True(t, errors.Is(err, c.want), "%v", err)
+		})
+	}
+
+	_, err := NewOrderBuilder("EUR").Product("Tea", 1, "1.00", "21").Product("Coffee", 1, "x", "21").Build(Order{})
+	assert.EqualError(t, err, `order_error: line 1 (Coffee): "x": amounts must be decimal numbers using the decimals of the currency`)
+}
+
+func TestOrder_ValidateAmounts(t *testing.T) {
+	eur := func(v string) *Amount { return &Amount{Currency: "EUR", Value: v} }
+
+	valid := func() *Order {
+		return &Order{
+			Amount: eur("1027.99"),
+			Lines: []*OrderLine{
+				{Name: "LEGO", Quantity: 2, UnitPrice: eur("399.00"), DiscountAmount: eur("100.00"), TotalAmount: eur("698.00"), VatRate: "21.00", VatAmount: eur("121.14")},
+				{Name: "Gift card", Quantity: 1, UnitPrice: eur("329.99"), TotalAmount: eur("329.99"), VatRate: "0.00", VatAmount: eur("0.00")},
+			},
+		}
+	}
+
+	require.Nil(t, valid().ValidateAmounts())
+
+	cases := []struct {
+		name   string
+		change func(o *Order)
+		want   error
+	}{
+		{"no lines", func(o *Order) { o.Lines = nil }, errOrderNoLines},
+		{"no amount", func(o *Order) { o.Amount = nil }, errOrderNoCurrency},
+		{"line total", func(o *Order) { o.Lines[0].TotalAmount = eur("798.00") }, errOrderLineTotal},
+		{"vat amount", func(o *Order) { o.Lines[0].VatAmount = eur("121.13") }, errOrderLineVat},
+		{"order amount", func(o *Order) { o.Amount = eur("1028.00") }, errOrderAmount},
+		{"currency", func(o *Order) { o.Lines[1].UnitPrice = &Amount{Currency: "USD", Value: "329.99"} }, errOrderCurrency},
+		{"missing amounts", func(o *Order) { o.Lines[1].VatAmount = nil }, errOrderLineAmount},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			o := valid()
+			c.change(o)
+
+			err := o.ValidateAmounts()
+			assert.True(t, errors.Is(err, c.want), "%v", err)
+		})
+	}
+
+	o := valid()
+	o.Lines[0].VatAmount = eur("121.13")
+	assert.EqualError(t, o.ValidateAmounts(),
+		"order_error: line 0 (LEGO): 121.13, expected 121.14: vat amount must equal total amount × (vat rate / (100 + vat rate))")
+}
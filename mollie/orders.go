@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -42,9 +43,12 @@ type Order struct {
 	OrderPayment                             *OrderPayment `json:"payment,omitempty"`
 	Lines                                    []*OrderLine  `json:"lines,omitempty"`
 	Embedded                                 struct {
-		Payments []*Payment `json:"payments,omitempty"`
-		Refunds  []*Refund  `json:"refunds,omitempty"`
+		Payments  []*Payment  `json:"payments,omitempty"`
+		Refunds   []*Refund   `json:"refunds,omitempty"`
+		Shipments []*Shipment `json:"shipments,omitempty"`
 	} `json:"_embedded,omitempty"`
+
+	*rawJSON
 }
 
 // OrderPayment describes payment specific parameters that can be passed during order creation.
@@ -186,10 +190,29 @@ type OrderLineLinks struct {
 	ImageURL   *URL `json:"imageUrl,omitempty"`
 }
 
+// OrderEmbeds is a set of resources embedded in orders: EmbedPayments,
+// EmbedRefunds and EmbedShipments.
+type OrderEmbeds []EmbedValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (e OrderEmbeds) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, e, EmbedPayments, EmbedRefunds, EmbedShipments)
+}
+
+// Has reports whether the set contains the value.
+func (e OrderEmbeds) Has(value EmbedValue) bool {
+	return contains(e, value)
+}
+
+// With returns the set with the value added when missing.
+func (e OrderEmbeds) With(value EmbedValue) OrderEmbeds {
+	return with(e, value)
+}
+
 // OrderOptions describes order endpoint valid query string parameters.
 type OrderOptions struct {
-	Embed     []EmbedValue `url:"embed,omitempty"`
-	ProfileID string       `url:"profileId,omitempty"`
+	Embed     OrderEmbeds `url:"embed,omitempty"`
+	ProfileID string      `url:"profileId,omitempty"`
 }
 
 // OrderListOptions describes order endpoint valid query string parameters.
@@ -201,9 +224,9 @@ type OrderListOptions struct {
 
 // OrderListRefundOptions describes order endpoint valid query string parameters.
 type OrderListRefundOptions struct {
-	From  string     `url:"from,omitempty"`
-	Limit int        `url:"limit,omitempty"`
-	Embed EmbedValue `url:"embed,omitempty"`
+	From  string       `url:"from,omitempty"`
+	Limit int          `url:"limit,omitempty"`
+	Embed RefundEmbeds `url:"embed,omitempty"`
 }
 
 // OrdersService instance operates over refund resources.
@@ -229,6 +252,10 @@ func (ors *OrdersService) Get(ctx context.Context, orID string, opts *OrderOptio
 //
 // See https://docs.mollie.com/reference/v2/orders-api/create-order
 func (ors *OrdersService) Create(ctx context.Context, ord Order, opts *OrderOptions) (res *Response, order *Order, err error) {
+	if err = ors.client.validate(&ord); err != nil {
+		return
+	}
+
 	if ors.client.HasAccessToken() && ors.client.config.testing {
 		ord.TestMode = true
 	}
@@ -249,6 +276,10 @@ func (ors *OrdersService) Create(ctx context.Context, ord Order, opts *OrderOpti
 //
 // See https://docs.mollie.com/reference/v2/orders-api/update-order
 func (ors *OrdersService) Update(ctx context.Context, orderID string, ord Order) (res *Response, order *Order, err error) {
+	if err = ors.client.validate(&ord); err != nil {
+		return
+	}
+
 	res, err = ors.client.patch(ctx, fmt.Sprintf("v2/orders/%s", orderID), ord, nil)
 	if err != nil {
 		return
@@ -293,6 +324,24 @@ func (ors *OrdersService) List(ctx context.Context, opts *OrderListOptions) (res
 	return
 }
 
+// Iterate returns an iterator over all the orders.
+//
+// See https://docs.mollie.com/reference/v2/orders-api/list-orders
+func (ors *OrdersService) Iterate(opts *OrderListOptions) *Iterator[*Order] {
+	return newListIterator[*Order](ors.client, func(from string) (string, interface{}) {
+		o := OrderListOptions{}
+		if opts != nil {
+			o = *opts
+		}
+
+		if from != "" {
+			o.From = from
+		}
+
+		return "v2/orders", &o
+	})
+}
+
 // UpdateOrderLine can be used to update an order line.
 //
 // See https://docs.mollie.com/reference/v2/orders-api/update-orderline
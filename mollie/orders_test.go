@@ -1193,6 +1193,29 @@ func (os *ordersServiceSuite) TestOrdersService_ListOrderRefund() {
 		})
 	}
 }
+func (os *ordersServiceSuite) TestOrdersService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/orders", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(os.T(), r, "GET")
+
+		if r.URL.Query().Get("from") == "" {
+			_, _ = w.Write([]byte(testdata.OrderListResponse))
+			return
+		}
+
+		testQuery(os.T(), r, "from=ord_stTC2WHAuS&testmode=true")
+		_, _ = w.Write([]byte(`{"count": 1, "_embedded": {"orders": [{"resource": "order", "id": "ord_stTC2WHAuS"}]}, "_links": {}}`))
+	})
+
+	orders, err := tClient.Orders.Iterate(nil).All(context.Background())
+	os.Nil(err)
+	os.Len(orders, 4)
+	os.Equal("ord_kEn1PlbGa", orders[0].ID)
+	os.Equal("ord_stTC2WHAuS", orders[3].ID)
+}
+
 func TestOrdersService(t *testing.T) {
 	suite.Run(t, new(ordersServiceSuite))
 }
@@ -19,6 +19,8 @@ type Organization struct {
 	VatNumber          string            `json:"vatNumber,omitempty"`
 	VatRegulation      string            `json:"vatRegulation,omitempty"`
 	Links              OrganizationLinks `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // OrganizationLinks describes all the possible links to be returned with
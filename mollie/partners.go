@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -40,9 +41,28 @@ type Commission struct {
 	TotalAmount *Amount `json:"totalAmount,omitempty"`
 }
 
+// PartnerEmbeds is a set of resources embedded in partner clients:
+// EmbedOrganization and EmbedOnboarding.
+type PartnerEmbeds []EmbedValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (e PartnerEmbeds) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, e, EmbedOrganization, EmbedOnboarding)
+}
+
+// Has reports whether the set contains the value.
+func (e PartnerEmbeds) Has(value EmbedValue) bool {
+	return contains(e, value)
+}
+
+// With returns the set with the value added when missing.
+func (e PartnerEmbeds) With(value EmbedValue) PartnerEmbeds {
+	return with(e, value)
+}
+
 // GetPartnerClientOptions contains valid query parameters for the get clients endpoint.
 type GetPartnerClientOptions struct {
-	Embed string `url:"embed,omitempty"`
+	Embed PartnerEmbeds `url:"embed,omitempty"`
 }
 
 // ListPartnerClientsOptions contains valid query parameters for the list clients endpoint.
@@ -58,7 +58,7 @@ func (os *partnersServiceSuite) TestPartnerService_Get() {
 				context.Background(),
 				"org_1337",
 				&GetPartnerClientOptions{
-					Embed: "organization",
+					Embed: PartnerEmbeds{EmbedOrganization},
 				},
 			},
 			false,
@@ -29,6 +29,10 @@ const (
 	ReasonInactiveCard                 FailureReason = "inactive_card"
 	ReasonUnknown                      FailureReason = "unknown_reason"
 	ReasonPossibleFraud                FailureReason = "possible_fraud"
+	ReasonAuthenticationFailed         FailureReason = "authentication_failed"
+	ReasonAuthenticationRequired       FailureReason = "authentication_required"
+	ReasonCardDeclined                 FailureReason = "card_declined"
+	ReasonTemporaryFailure             FailureReason = "temporary_failure"
 )
 
 // EligibilityReasons for paypal seller protection.
@@ -78,6 +82,7 @@ type PaymentDetails struct {
 	MandateReference   string                 `json:"mandateReference,omitempty"`
 	PaypalReference    string                 `json:"paypalReference,omitempty"`
 	PaypalPayerID      string                 `json:"paypalPayerId,omitempty"`
+	TerminalID         string                 `json:"terminalId,omitempty"`
 	TransferReference  string                 `json:"transferReference,omitempty"`
 	VoucherNumber      string                 `json:"voucherNumber,omitempty"`
 	Wallet             string                 `json:"wallet,omitempty"`
@@ -113,3 +118,199 @@ type PaymentDetailsAddress struct {
 	Region           string      `json:"region,omitempty"`
 	Country          string      `json:"country,omitempty"`
 }
+
+// CreditCardDetails are the details of card payments, including the ones
+// paid with a wallet like Apple Pay.
+type CreditCardDetails struct {
+	CardHolder      string
+	CardNumber      string
+	CardFingerprint string
+	CardAudience    string
+	CardLabel       CardLabel
+	CardCountryCode string
+	CardSecurity    string
+	Wallet          string
+	FeeRegion       FeeRegion
+	FailureReason   FailureReason
+}
+
+// BankTransferDetails are the details of bank transfer payments, the
+// bank fields describe the account the customer has to transfer to.
+type BankTransferDetails struct {
+	BankName          string
+	BankAccount       string
+	BankBIC           string
+	TransferReference string
+	ConsumerName      string
+	ConsumerAccount   string
+	ConsumerBIC       string
+	BillingEmail      string
+	DueDate           *ShortDate
+	QRCode            *QRCode
+	StatusURL         *URL
+	PayOnlineURL      *URL
+}
+
+// PayPalDetails are the details of PayPal payments.
+type PayPalDetails struct {
+	ConsumerName     string
+	ConsumerAccount  string
+	PaypalReference  string
+	PaypalPayerID    string
+	SellerProtection EligibilityReasons
+	ShippingAddress  *PaymentDetailsAddress
+	PaypalFee        *Amount
+	DigitalGoods     bool
+}
+
+// DirectDebitDetails are the details of SEPA direct debit payments.
+type DirectDebitDetails struct {
+	ConsumerName       string
+	ConsumerAccount    string
+	ConsumerBIC        string
+	TransferReference  string
+	CreditorIdentifier string
+	DueDate            *ShortDate
+	SignatureDate      *ShortDate
+	BankReason         string
+	EndToEndIdentifier string
+	MandateReference   string
+	BatchReference     string
+	FileReference      string
+}
+
+// GiftCardDetails are the details of gift card payments, the remainder
+// is paid with another method when the gift cards don't cover the
+// amount.
+type GiftCardDetails struct {
+	VoucherNumber   string
+	GiftCards       []*UsedGiftCard
+	RemainderAmount *Amount
+	RemainderMethod PaymentMethod
+}
+
+// The details accessors return nil when the payment has no details or is
+// paid with another method, so checking the result is enough to know
+// which method applies.
+
+// CreditCardDetails returns the details of a credit card or Apple Pay
+// payment, nil for other methods.
+func (p *Payment) CreditCardDetails() *CreditCardDetails {
+	d := p.details(CreditCard, ApplePay)
+	if d == nil {
+		return nil
+	}
+
+	return &CreditCardDetails{
+		CardHolder:      d.CardHolder,
+		CardNumber:      d.CardNumber,
+		CardFingerprint: d.CardFingerPrint,
+		CardAudience:    d.CardAudience,
+		CardLabel:       CardLabel(d.CardLabel),
+		CardCountryCode: d.CardCountryCode,
+		CardSecurity:    d.CardSecurity,
+		Wallet:          d.Wallet,
+		FeeRegion:       d.FeeRegion,
+		FailureReason:   d.FailureReason,
+	}
+}
+
+// BankTransferDetails returns the details of a bank transfer payment,
+// nil for other methods.
+func (p *Payment) BankTransferDetails() *BankTransferDetails {
+	d := p.details(BankTransfer)
+	if d == nil {
+		return nil
+	}
+
+	return &BankTransferDetails{
+		BankName:          d.BankName,
+		BankAccount:       d.BankAccount,
+		BankBIC:           d.BankBIC,
+		TransferReference: d.TransferReference,
+		ConsumerName:      d.ConsumerName,
+		ConsumerAccount:   d.ConsumerAccount,
+		ConsumerBIC:       d.ConsumerBIC,
+		BillingEmail:      d.BillingEmail,
+		DueDate:           d.DueDate,
+		QRCode:            d.QRCode,
+		StatusURL:         d.Links.Status,
+		PayOnlineURL:      d.Links.PayOnline,
+	}
+}
+
+// PayPalDetails returns the details of a PayPal payment, nil for other
+// methods. PaypalFee is nil when no fee was charged.
+func (p *Payment) PayPalDetails() *PayPalDetails {
+	d := p.details(PayPal)
+	if d == nil {
+		return nil
+	}
+
+	pd := &PayPalDetails{
+		ConsumerName:     d.ConsumerName,
+		ConsumerAccount:  d.ConsumerAccount,
+		PaypalReference:  d.PaypalReference,
+		PaypalPayerID:    d.PaypalPayerID,
+		SellerProtection: d.SellerProtection,
+		ShippingAddress:  d.ShippingAddress,
+		DigitalGoods:     d.PaypalDigitalGoods,
+	}
+
+	if d.PaypalFee != (Amount{}) {
+		fee := d.PaypalFee
+		pd.PaypalFee = &fee
+	}
+
+	return pd
+}
+
+// DirectDebitDetails returns the details of a SEPA direct debit payment,
+// nil for other methods.
+func (p *Payment) DirectDebitDetails() *DirectDebitDetails {
+	d := p.details(DirectDebit)
+	if d == nil {
+		return nil
+	}
+
+	return &DirectDebitDetails{
+		ConsumerName:       d.ConsumerName,
+		ConsumerAccount:    d.ConsumerAccount,
+		ConsumerBIC:        d.ConsumerBIC,
+		TransferReference:  d.TransferReference,
+		CreditorIdentifier: d.CreditorIdentifier,
+		DueDate:            d.DueDate,
+		SignatureDate:      d.SignatureDate,
+		BankReason:         d.BankReason,
+		EndToEndIdentifier: d.EndToEndIdentifier,
+		MandateReference:   d.MandateReference,
+		BatchReference:     d.BatchReference,
+		FileReference:      d.FileReference,
+	}
+}
+
+// GiftCardDetails returns the details of a gift card payment, nil for
+// other methods.
+func (p *Payment) GiftCardDetails() *GiftCardDetails {
+	d := p.details(GiftCard)
+	if d == nil {
+		return nil
+	}
+
+	return &GiftCardDetails{
+		VoucherNumber:   d.VoucherNumber,
+		GiftCards:       d.GiftCards,
+		RemainderAmount: d.RemainderAmount,
+		RemainderMethod: d.RemainderMethod,
+	}
+}
+
+// details returns the details of the payment when it is paid with one of
+// the methods.
+func (p *Payment) details(methods ...PaymentMethod) *PaymentDetails {
+	if p.Details == nil || !contains(methods, p.Method) {
+		return nil
+	}
+
+	return p.Details
+}
@@ -0,0 +1,153 @@
+package mollie
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayment_DetailsAccessors(t *testing.T) {
+	decode := func(t *testing.T, body string) *Payment {
+		t.Helper()
+
+		var p Payment
+		require.Nil(t, json.Unmarshal([]byte(body), &p))
+
+		return &p
+	}
+
+	t.Run("credit card", func(t *testing.T) {
+		p := decode(t, `{"method": "creditcard", "details": {
+			"cardNumber": "6787",
+			"cardHolder": "T. TEST",
+			"cardFingerprint": "Q8wNFXhvKg7fYpB0BMN8W6vA",
+			"cardLabel": "Visa",
+			"cardCountryCode": "NL",
+			"cardSecurity": "3dsecure",
+			"feeRegion": "intra-eu",
+			"failureReason": "card_expired"
+		}}`)
+
+		d := p.CreditCardDetails()
+		require.NotNil(t, d)
+		assert.Equal(t, &CreditCardDetails{
+			CardHolder:      "T. TEST",
+			CardNumber:      "6787",
+			CardFingerprint: "Q8wNFXhvKg7fYpB0BMN8W6vA",
+			CardLabel:       Visa,
+			CardCountryCode: "NL",
+			CardSecurity:    "3dsecure",
+			FeeRegion:       IntraEU,
+			FailureReason:   ReasonCardExpired,
+		}, d)
+
+		assert.Nil(t, p.BankTransferDetails())
+		assert.Nil(t, p.PayPalDetails())
+		assert.Nil(t, p.DirectDebitDetails())
+		assert.Nil(t, p.GiftCardDetails())
+	})
+
+	t.Run("apple pay", func(t *testing.T) {
+		p := decode(t, `{"method": "applepay", "details": {"cardLabel": "Mastercard", "wallet": "applepay"}}`)
+
+		d := p.CreditCardDetails()
+		require.NotNil(t, d)
+		assert.Equal(t, Mastercard, d.CardLabel)
+		assert.Equal(t, "applepay", d.Wallet)
+	})
+
+	t.Run("bank transfer", func(t *testing.T) {
+		p := decode(t, testdata.CancelPaymentResponse)
+		require.Equal(t, BankTransfer, p.Method)
+
+		d := p.BankTransferDetails()
+		require.NotNil(t, d)
+		assert.Equal(t, "Stichting Mollie Payments", d.BankName)
+		assert.Equal(t, "RF12-3456-7890-1234", d.TransferReference)
+		assert.Nil(t, p.CreditCardDetails())
+	})
+
+	t.Run("paypal", func(t *testing.T) {
+		p := decode(t, `{"method": "paypal", "details": {
+			"consumerName": "Piet Mondriaan",
+			"consumerAccount": "piet@mondriaan.com",
+			"paypalReference": "9AL35361CF606152E",
+			"paypalPayerId": "WDJJHEBZ4X2LY",
+			"sellerProtection": "Eligible",
+			"paypalFee": {"currency": "EUR", "value": "0.56"}
+		}}`)
+
+		d := p.PayPalDetails()
+		require.NotNil(t, d)
+		assert.Equal(t, "9AL35361CF606152E", d.PaypalReference)
+		assert.Equal(t, Eligible, d.SellerProtection)
+		assert.Equal(t, &Amount{Currency: "EUR", Value: "0.56"}, d.PaypalFee)
+
+		p.Details.PaypalFee = Amount{}
+		assert.Nil(t, p.PayPalDetails().PaypalFee)
+	})
+
+	t.Run("direct debit", func(t *testing.T) {
+		p := decode(t, `{"method": "directdebit", "details": {
+			"consumerName": "John Doe",
+			"consumerAccount": "NL55INGB0000000000",
+			"consumerBic": "INGBNL2A",
+			"creditorIdentifier": "NL08ZZZ502057730000",
+			"dueDate": "2018-05-07",
+			"bankReason": "Insufficient funds"
+		}}`)
+
+		d := p.DirectDebitDetails()
+		require.NotNil(t, d)
+		assert.Equal(t, "NL55INGB0000000000", d.ConsumerAccount)
+		assert.Equal(t, "NL08ZZZ502057730000", d.CreditorIdentifier)
+		assert.Equal(t, "Insufficient funds", d.BankReason)
+		require.NotNil(t, d.DueDate)
+		assert.Equal(t, "2018-05-07", d.DueDate.Format("2006-01-02"))
+	})
+
+	t.Run("gift card", func(t *testing.T) {
+		p := decode(t, `{"method": "giftcard", "details": {
+			"giftCards": [{"issuer": "fashioncheque", "amount": {"currency": "EUR", "value": "10.00"}, "voucherNumber": "*****3000"}],
+			"remainderAmount": {"currency": "EUR", "value": "5.00"},
+			"remainderMethod": "ideal"
+		}}`)
+
+		d := p.GiftCardDetails()
+		require.NotNil(t, d)
+		require.Len(t, d.GiftCards, 1)
+		assert.Equal(t, "fashioncheque", d.GiftCards[0].Issuer)
+		assert.Equal(t, IDeal, d.RemainderMethod)
+	})
+
+	t.Run("without details", func(t *testing.T) {
+		p := decode(t, `{"method": "creditcard"}`)
+		assert.Nil(t, p.CreditCardDetails())
+	})
+}
+
+func TestMandate_DetailsAccessors(t *testing.T) {
+	var ml MandatesList
+	require.Nil(t, json.Unmarshal([]byte(testdata.ListMandatesLastPageResponse), &ml))
+	require.Len(t, ml.Embedded.Mandates, 2)
+
+	dd, card := ml.Embedded.Mandates[0], ml.Embedded.Mandates[1]
+
+	assert.Equal(t, &MandateDirectDebitDetails{
+		ConsumerName:    "John Doe",
+		ConsumerAccount: "NL55INGB0000000000",
+		ConsumerBic:     "INGBNL2A",
+	}, dd.DirectDebitDetails())
+	assert.Nil(t, dd.CardDetails())
+
+	d := card.CardDetails()
+	require.NotNil(t, d)
+	assert.Equal(t, Mastercard, d.CardLabel)
+	assert.Equal(t, "fHB3CCKx9REkz8fPplT8N4nq", d.CardFingerprint)
+	require.NotNil(t, d.CardExpiryDate)
+	assert.Equal(t, "2016-03-31", d.CardExpiryDate.Format("2006-01-02"))
+	assert.Nil(t, card.DirectDebitDetails())
+}
@@ -26,6 +26,8 @@ type PaymentLink struct {
 	UpdatedAt   *time.Time       `json:"updatedAt,omitempty"`
 	ExpiresAt   *time.Time       `json:"expiresAt,omitempty"`
 	Links       PaymentLinkLinks `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // PaymentLinkLinks describes all the possible links returned with
@@ -81,6 +83,10 @@ func (pls *PaymentLinksService) Get(ctx context.Context, id string) (res *Respon
 //
 // See: https://docs.mollie.com/reference/v2/payment-links-api/create-payment-link
 func (pls *PaymentLinksService) Create(ctx context.Context, p PaymentLink, opts *PaymentLinkOptions) (res *Response, np *PaymentLink, err error) {
+	if err = pls.client.validate(&p); err != nil {
+		return
+	}
+
 	res, err = pls.client.post(ctx, "v2/payment-links", p, opts)
 	if err != nil {
 		return
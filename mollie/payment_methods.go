@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // PaymentMethodStatus tels the status that the method is in.
@@ -67,13 +68,32 @@ type PaymentMethodsList struct {
 	Links PaginationLinks `json:"_links,omitempty"`
 }
 
+// MethodIncludes is a set of details included in payment methods:
+// IncludeIssuers and IncludePricing.
+type MethodIncludes []IncludeValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (i MethodIncludes) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, i, IncludeIssuers, IncludePricing)
+}
+
+// Has reports whether the set contains the value.
+func (i MethodIncludes) Has(value IncludeValue) bool {
+	return contains(i, value)
+}
+
+// With returns the set with the value added when missing.
+func (i MethodIncludes) With(value IncludeValue) MethodIncludes {
+	return with(i, value)
+}
+
 // PaymentMethodOptions are applicable query string parameters to get methods
 // from mollie's API.
 type PaymentMethodOptions struct {
-	Locale    Locale `url:"locale,omitempty"`
-	Currency  string `url:"currency,omitempty"`
-	ProfileID string `url:"profileId,omitempty"`
-	Include   string `url:"include,omitempty"`
+	Locale    Locale         `url:"locale,omitempty"`
+	Currency  string         `url:"currency,omitempty"`
+	ProfileID string         `url:"profileId,omitempty"`
+	Include   MethodIncludes `url:"include,omitempty"`
 }
 
 // PaymentMethodsListOptions are applicable query string parameters to list methods
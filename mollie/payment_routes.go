@@ -0,0 +1,150 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var (
+	errRoutingWithoutAmount  = errors.New("payment amount is required when routing is provided")
+	errRoutingCurrency       = errors.New("route currency must match the payment currency")
+	errRoutingInvalidAmount  = errors.New("route amount must be a positive decimal value")
+	errRoutingExceedsPayment = errors.New("the sum of the routes exceeds the payment amount")
+)
+
+// PaymentDestinationType describes the type of a route destination.
+type PaymentDestinationType string
+
+// Supported payment route destination types.
+const (
+	OrganizationDestination PaymentDestinationType = "organization"
+)
+
+// PaymentRouteDestination describes where a portion of a payment is sent to.
+type PaymentRouteDestination struct {
+	Type           PaymentDestinationType `json:"type,omitempty"`
+	OrganizationID string                 `json:"organizationId,omitempty"`
+}
+
+// PaymentRouteLinks contains URL objects relevant to a payment route.
+type PaymentRouteLinks struct {
+	Self          *URL `json:"self,omitempty"`
+	Payment       *URL `json:"payment,omitempty"`
+	Documentation *URL `json:"documentation,omitempty"`
+}
+
+// PaymentRouting describes a portion of a payment that is routed
+// to the balance of a connected organization.
+//
+// When the release date is omitted the funds become available on the
+// connected balance as soon as the payment is settled.
+type PaymentRouting struct {
+	Resource    string                   `json:"resource,omitempty"`
+	ID          string                   `json:"id,omitempty"`
+	PaymentID   string                   `json:"paymentId,omitempty"`
+	Amount      *Amount                  `json:"amount,omitempty"`
+	Destination *PaymentRouteDestination `json:"destination,omitempty"`
+	ReleaseDate *ShortDate               `json:"releaseDate,omitempty"`
+	CreatedAt   *time.Time               `json:"createdAt,omitempty"`
+	Links       *PaymentRouteLinks       `json:"_links,omitempty"`
+}
+
+// PaymentRoutesList describes a list of routes attached to a payment.
+type PaymentRoutesList struct {
+	Count    int `json:"count,omitempty"`
+	Embedded struct {
+		Routes []*PaymentRouting `json:"routes,omitempty"`
+	} `json:"_embedded,omitempty"`
+	Links PaginationLinks `json:"_links,omitempty"`
+}
+
+// ValidateRouting checks that every route uses the payment currency
+// and that the sum of all the routes never exceeds the payment amount.
+func (p *Payment) ValidateRouting() error {
+	if err := p.checkRouting(); err != nil {
+		return fmt.Errorf("routing_error: %w", err)
+	}
+
+	return nil
+}
+
+// checkRouting is ValidateRouting without the error prefix, the request
+// validation uses its errors as field details.
+func (p *Payment) checkRouting() error {
+	if len(p.Routing) == 0 {
+		return nil
+	}
+
+	if p.Amount == nil {
+		return errRoutingWithoutAmount
+	}
+
+	total, ok := new(big.Rat).SetString(p.Amount.Value)
+	if !ok {
+		return errRoutingInvalidAmount
+	}
+
+	sum := new(big.Rat)
+
+	for i, r := range p.Routing {
+		if r.Amount == nil {
+			return fmt.Errorf("route %d: %w", i, errRoutingInvalidAmount)
+		}
+
+		if r.Amount.Currency != p.Amount.Currency {
+			return fmt.Errorf("route %d: %w", i, errRoutingCurrency)
+		}
+
+		v, ok := new(big.Rat).SetString(r.Amount.Value)
+		if !ok || v.Sign() <= 0 {
+			return fmt.Errorf("route %d: %w", i, errRoutingInvalidAmount)
+		}
+
+		sum.Add(sum, v)
+	}
+
+	if sum.Cmp(total) > 0 {
+		return errRoutingExceedsPayment
+	}
+
+	return nil
+}
+
+// ListRoutes retrieves all the routes created for a payment.
+//
+// See: https://docs.mollie.com/reference/v2/payments-api/list-payment-routes
+func (ps *PaymentsService) ListRoutes(ctx context.Context, payment string) (res *Response, rl *PaymentRoutesList, err error) {
+	res, err = ps.client.get(ctx, fmt.Sprintf("v2/payments/%s/routes", payment), nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &rl); err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateRoute changes the release date of a route, the amount and
+// destination of a route can't be modified once the payment is created.
+//
+// See: https://docs.mollie.com/reference/v2/payments-api/update-payment-route
+func (ps *PaymentsService) UpdateRoute(ctx context.Context, payment, route string, releaseDate *ShortDate) (res *Response, r *PaymentRouting, err error) {
+	u := fmt.Sprintf("v2/payments/%s/routes/%s", payment, route)
+
+	res, err = ps.client.patch(ctx, u, PaymentRouting{ReleaseDate: releaseDate}, nil)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
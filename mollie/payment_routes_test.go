@@ -0,0 +1,350 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/suite"
+)
+
+type paymentRoutesServiceSuite struct{ suite.Suite }
+
+func (ps *paymentRoutesServiceSuite) SetupSuite() { setEnv() }
+
+func (ps *paymentRoutesServiceSuite) TearDownSuite() { unsetEnv() }
+
+func (ps *paymentRoutesServiceSuite) TestPayment_ValidateRouting() {
+	route := func(currency, value string) *PaymentRouting {
+		return &PaymentRouting{
+			Amount: &Amount{Currency: currency, Value: value},
+			Destination: &PaymentRouteDestination{
+				Type:           OrganizationDestination,
+				OrganizationID: "org_23456",
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		payment Payment
+		err     error
+	}{
+		{
+			"payments without routing are valid.",
+			Payment{Amount: &Amount{Currency: "EUR", Value: "10.00"}},
+			nil,
+		},
+		{
+			"routes summing up to the payment amount are valid.",
+			Payment{
+				Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+				Routing: []*PaymentRouting{route("EUR", "7.50"), route("EUR", "2.50")},
+			},
+			nil,
+		},
+		{
+			"routes below the payment amount are valid.",
+			Payment{
+				Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+				Routing: []*PaymentRouting{route("EUR", "5.00")},
+			},
+			nil,
+		},
+		{
+			"routes exceeding the payment amount are rejected.",
+			Payment{
+				Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+				Routing: []*PaymentRouting{route("EUR", "7.50"), route("EUR", "2.51")},
+			},
+			errRoutingExceedsPayment,
+		},
+		{
+			"routes with a different currency are rejected.",
+			Payment{
+				Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+				Routing: []*PaymentRouting{route("USD", "1.00")},
+			},
+			errRoutingCurrency,
+		},
+		{
+			"routes with a non positive amount are rejected.",
+			Payment{
+				Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+				Routing: []*PaymentRouting{route("EUR", "-1.00")},
+			},
+			errRoutingInvalidAmount,
+		},
+		{
+			"routes without amount are rejected.",
+			Payment{
+				Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+				Routing: []*PaymentRouting{{}},
+			},
+			errRoutingInvalidAmount,
+		},
+		{
+			"routing requires a payment amount.",
+			Payment{
+				Routing: []*PaymentRouting{route("EUR", "1.00")},
+			},
+			errRoutingWithoutAmount,
+		},
+	}
+
+	for _, c := range cases {
+		ps.T().Run(c.name, func(t *testing.T) {
+			err := c.payment.ValidateRouting()
+			if c.err == nil {
+				ps.Nil(err)
+			} else {
+				ps.True(errors.Is(err, c.err))
+			}
+		})
+	}
+}
+
+func (ps *paymentRoutesServiceSuite) TestPaymentsService_CreateWithRouting() {
+	setup()
+	defer teardown()
+
+	called := false
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+	})
+
+	_, _, err := tClient.Payments.Create(context.Background(), Payment{
+		Amount: &Amount{Currency: "EUR", Value: "10.00"},
+		Routing: []*PaymentRouting{
+			{Amount: &Amount{Currency: "EUR", Value: "10.01"}},
+		},
+	}, nil)
+
+	ps.True(errors.Is(err, errRoutingExceedsPayment))
+	ps.False(called)
+
+	tClient.config.WithValidation(false)
+
+	_, _, err = tClient.Payments.Create(context.Background(), Payment{
+		Amount: &Amount{Currency: "EUR", Value: "10.00"},
+		Routing: []*PaymentRouting{
+			{Amount: &Amount{Currency: "EUR", Value: "10.01"}},
+		},
+	}, nil)
+
+	ps.Nil(err)
+	ps.True(called)
+}
+
+func (ps *paymentRoutesServiceSuite) TestPaymentsService_ListRoutes() {
+	type args struct {
+		ctx     context.Context
+		payment string
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"list payment routes works as expected.",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(ps.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(ps.T(), r, "GET")
+				testQuery(ps.T(), r, "testmode=true")
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				_, _ = w.Write([]byte(testdata.ListPaymentRoutesResponse))
+			},
+		},
+		{
+			"list payment routes, an error is returned from the server",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			errorHandler,
+		},
+		{
+			"list payment routes, an error occurs when parsing json",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+			},
+			true,
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			noPre,
+			encodingHandler,
+		},
+		{
+			"list payment routes, invalid url when building request",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+			},
+			true,
+			errBadBaseURL,
+			crashSrv,
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		ps.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc(fmt.Sprintf("/v2/payments/%s/routes", c.args.payment), c.handler)
+
+			res, rl, err := tClient.Payments.ListRoutes(c.args.ctx, c.args.payment)
+			if c.wantErr {
+				ps.NotNil(err)
+				ps.EqualError(err, c.err.Error())
+			} else {
+				ps.Nil(err)
+				ps.IsType(&PaymentRoutesList{}, rl)
+				ps.Len(rl.Embedded.Routes, 2)
+				ps.Equal("org_23456", rl.Embedded.Routes[0].Destination.OrganizationID)
+				ps.Equal("2022-11-28", rl.Embedded.Routes[0].ReleaseDate.Format("2006-01-02"))
+				ps.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func (ps *paymentRoutesServiceSuite) TestPaymentsService_UpdateRoute() {
+	type args struct {
+		ctx         context.Context
+		payment     string
+		route       string
+		releaseDate *ShortDate
+	}
+
+	releaseDate := &ShortDate{time.Date(2022, 12, 24, 0, 0, 0, 0, time.UTC)}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"update payment route works as expected.",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+				"rt_k6cjd01h",
+				releaseDate,
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(ps.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(ps.T(), r, "PATCH")
+				testQuery(ps.T(), r, "testmode=true")
+
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				ps.Equal("2022-12-24", body["releaseDate"])
+				ps.NotContains(body, "amount")
+				ps.NotContains(body, "destination")
+				ps.NotContains(body, "_links")
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				_, _ = w.Write([]byte(testdata.UpdatePaymentRouteResponse))
+			},
+		},
+		{
+			"update payment route, an error is returned from the server",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+				"rt_k6cjd01h",
+				releaseDate,
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			errorHandler,
+		},
+		{
+			"update payment route, an error occurs when parsing json",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+				"rt_k6cjd01h",
+				releaseDate,
+			},
+			true,
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			noPre,
+			encodingHandler,
+		},
+		{
+			"update payment route, invalid url when building request",
+			args{
+				context.Background(),
+				"tr_7UhSN1zuXS",
+				"rt_k6cjd01h",
+				releaseDate,
+			},
+			true,
+			errBadBaseURL,
+			crashSrv,
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		ps.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc(fmt.Sprintf("/v2/payments/%s/routes/%s", c.args.payment, c.args.route), c.handler)
+
+			res, r, err := tClient.Payments.UpdateRoute(c.args.ctx, c.args.payment, c.args.route, c.args.releaseDate)
+			if c.wantErr {
+				ps.NotNil(err)
+				ps.EqualError(err, c.err.Error())
+			} else {
+				ps.Nil(err)
+				ps.IsType(&PaymentRouting{}, r)
+				ps.Equal("2022-12-24", r.ReleaseDate.Format("2006-01-02"))
+				ps.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func TestPaymentRoutesService(t *testing.T) {
+	suite.Run(t, new(paymentRoutesServiceSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -12,23 +13,32 @@ type PaymentMethod string
 
 // Supported payment methods.
 const (
+	ApplePay       PaymentMethod = "applepay"
 	Bancontact     PaymentMethod = "bancontact"
 	BankTransfer   PaymentMethod = "banktransfer"
 	Belfius        PaymentMethod = "belfius"
+	Billie         PaymentMethod = "billie"
+	Blik           PaymentMethod = "blik"
 	CreditCard     PaymentMethod = "creditcard"
 	DirectDebit    PaymentMethod = "directdebit"
 	EPS            PaymentMethod = "eps"
 	GiftCard       PaymentMethod = "giftcard"
 	GiroPay        PaymentMethod = "giropay"
 	IDeal          PaymentMethod = "ideal"
+	In3            PaymentMethod = "in3"
 	KBC            PaymentMethod = "kbc"
 	KlarnaPayLater PaymentMethod = "klarnapaylater"
+	KlarnaPayNow   PaymentMethod = "klarnapaynow"
 	KlarnaLiceit   PaymentMethod = "klarnaliceit"
 	MyBank         PaymentMethod = "mybank"
 	PayPal         PaymentMethod = "paypal"
 	PaySafeCard    PaymentMethod = "paysafecard"
+	PointOfSale    PaymentMethod = "pointofsale"
 	PRZelewy24     PaymentMethod = "przelewy24"
+	Riverty        PaymentMethod = "riverty"
 	Sofort         PaymentMethod = "sofort"
+	Twint          PaymentMethod = "twint"
+	Voucher        PaymentMethod = "voucher"
 )
 
 // SequenceType indicates which type of payment this is in a recurring sequence.
@@ -41,6 +51,20 @@ const (
 	RecurringSequence SequenceType = "recurring"
 )
 
+// PaymentStatus describes the status of a payment.
+type PaymentStatus string
+
+// Valid payment status.
+const (
+	PaymentOpen       PaymentStatus = "open"
+	PaymentCanceled   PaymentStatus = "canceled"
+	PaymentPending    PaymentStatus = "pending"
+	PaymentAuthorized PaymentStatus = "authorized"
+	PaymentExpired    PaymentStatus = "expired"
+	PaymentFailed     PaymentStatus = "failed"
+	PaymentPaid       PaymentStatus = "paid"
+)
+
 // Payment describes a transaction between a customer and a merchant.
 type Payment struct {
 	IsCancellable                   bool                   `json:"isCancellable,omitempty"`
@@ -65,11 +89,12 @@ type Payment struct {
 	ProfileID                       string                 `json:"profileId,omitempty"`
 	SettlementID                    string                 `json:"settlementId,omitempty"`
 	CustomerID                      string                 `json:"customerId,omitempty"`
-	Status                          string                 `json:"status,omitempty"`
+	Status                          PaymentStatus          `json:"status,omitempty"`
 	Description                     string                 `json:"description,omitempty"`
 	RedirectURL                     string                 `json:"redirectUrl,omitempty"`
 	CountryCode                     string                 `json:"countryCode,omitempty"`
 	SubscriptionID                  string                 `json:"subscriptionId,omitempty"`
+	TerminalID                      string                 `json:"terminalId,omitempty"`
 	Metadata                        interface{}            `json:"metadata,omitempty"`
 	Amount                          *Amount                `json:"amount,omitempty"`
 	AmountRefunded                  *Amount                `json:"amountRefunded,omitempty"`
@@ -78,6 +103,7 @@ type Payment struct {
 	SettlementAmount                *Amount                `json:"settlementAmount,omitempty"`
 	ApplicationFee                  *ApplicationFee        `json:"applicationFee,omitempty"`
 	Details                         *PaymentDetails        `json:"details,omitempty"`
+	Routing                         []*PaymentRouting      `json:"routing,omitempty"`
 	CreatedAt                       *time.Time             `json:"createdAt,omitempty"`
 	AuthorizedAt                    *time.Time             `json:"authorizedAt,omitempty"`
 	PaidAt                          *time.Time             `json:"paidAt,omitempty"`
@@ -94,6 +120,19 @@ type Payment struct {
 	Method                          PaymentMethod          `json:"method,omitempty"`
 	Links                           PaymentLinks           `json:"_links,omitempty"`
 	SequenceType                    SequenceType           `json:"sequenceType,omitempty"`
+	Embedded                        *PaymentEmbedded       `json:"_embedded,omitempty"`
+
+	*rawJSON
+}
+
+// PaymentEmbedded contains the resources embedded in a payment, it is nil
+// unless the payment was retrieved with EmbedRefunds, EmbedChargebacks or
+// EmbedCaptures. Payments are sent when creating them, a pointer keeps
+// the field out of the requests.
+type PaymentEmbedded struct {
+	Refunds     []*Refund     `json:"refunds,omitempty"`
+	Chargebacks []*Chargeback `json:"chargebacks,omitempty"`
+	Captures    []*Capture    `json:"captures,omitempty"`
 }
 
 // PaymentLinks describes all the possible links to be returned with
@@ -111,24 +150,64 @@ type PaymentLinks struct {
 	Subscription       *URL `json:"subscription,omitempty"`
 	Customer           *URL `json:"customer,omitempty"`
 	Order              *URL `json:"order,omitempty"`
+	Routes             *URL `json:"routes,omitempty"`
+	Terminal           *URL `json:"terminal,omitempty"`
 	Dashboard          *URL `json:"dashboard,omitempty"`
 }
 
+// PaymentEmbeds is a set of resources embedded in payments: EmbedRefunds,
+// EmbedChargebacks and EmbedCaptures.
+type PaymentEmbeds []EmbedValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (e PaymentEmbeds) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, e, EmbedRefunds, EmbedChargebacks, EmbedCaptures)
+}
+
+// Has reports whether the set contains the value.
+func (e PaymentEmbeds) Has(value EmbedValue) bool {
+	return contains(e, value)
+}
+
+// With returns the set with the value added when missing.
+func (e PaymentEmbeds) With(value EmbedValue) PaymentEmbeds {
+	return with(e, value)
+}
+
+// PaymentIncludes is a set of details included in payments: IncludeQRCode
+// and IncludeRemainderDetails.
+type PaymentIncludes []IncludeValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (i PaymentIncludes) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, i, IncludeQRCode, IncludeRemainderDetails)
+}
+
+// Has reports whether the set contains the value.
+func (i PaymentIncludes) Has(value IncludeValue) bool {
+	return contains(i, value)
+}
+
+// With returns the set with the value added when missing.
+func (i PaymentIncludes) With(value IncludeValue) PaymentIncludes {
+	return with(i, value)
+}
+
 // PaymentOptions describes payments endpoint valid query string parameters.
 //
 // See: https://docs.mollie.com/reference/v2/payments-api/get-payment
 type PaymentOptions struct {
-	Include string `url:"include,omitempty"`
-	Embed   string `url:"embed,omitempty"`
+	Include PaymentIncludes `url:"include,omitempty"`
+	Embed   PaymentEmbeds   `url:"embed,omitempty"`
 }
 
 // ListPaymentOptions describes list payments endpoint valid query string parameters.
 type ListPaymentOptions struct {
-	Limit     int    `url:"limit,omitempty"`
-	Include   string `url:"include,omitempty"`
-	Embed     string `url:"embed,omitempty"`
-	ProfileID string `url:"profileId,omitempty"`
-	From      string `url:"from,omitempty"`
+	Limit     int             `url:"limit,omitempty"`
+	Include   PaymentIncludes `url:"include,omitempty"`
+	Embed     PaymentEmbeds   `url:"embed,omitempty"`
+	ProfileID string          `url:"profileId,omitempty"`
+	From      string          `url:"from,omitempty"`
 }
 
 // PaymentsService instance operates over payment resources.
@@ -150,8 +229,16 @@ func (ps *PaymentsService) Get(ctx context.Context, id string, opts *PaymentOpti
 
 // Create stores a new payment object attached to your Mollie account.
 //
+// The payment, routing included, is checked with Payment.Validate before
+// sending the request unless validation is disabled with
+// Config.WithValidation.
+//
 // See: https://docs.mollie.com/reference/v2/payments-api/create-payment#
 func (ps *PaymentsService) Create(ctx context.Context, p Payment, opts *PaymentOptions) (res *Response, np *Payment, err error) {
+	if err = ps.client.validate(&p); err != nil {
+		return
+	}
+
 	if ps.client.HasAccessToken() && ps.client.config.testing {
 		p.TestMode = true
 	}
@@ -188,6 +275,10 @@ func (ps *PaymentsService) Cancel(ctx context.Context, id string) (res *Response
 //
 // See: https://docs.mollie.com/reference/v2/payments-api/update-payment#
 func (ps *PaymentsService) Update(ctx context.Context, id string, up Payment) (res *Response, p *Payment, err error) {
+	if err = ps.client.validate(&up); err != nil {
+		return
+	}
+
 	res, err = ps.client.patch(ctx, fmt.Sprintf("v2/payments/%s", id), up, nil)
 	if err != nil {
 		return
@@ -208,6 +299,15 @@ type PaymentList struct {
 	Links PaginationLinks `json:"_links,omitempty"`
 }
 
+func (pl *PaymentList) items() []*Payment {
+	payments := make([]*Payment, len(pl.Embedded.Payments))
+	for i := range pl.Embedded.Payments {
+		payments[i] = &pl.Embedded.Payments[i]
+	}
+
+	return payments
+}
+
 // List retrieves a list of payments associated with your account/organization.
 //
 // See: https://docs.mollie.com/reference/v2/payments-api/list-payments
@@ -222,3 +322,22 @@ func (ps *PaymentsService) List(ctx context.Context, opts *ListPaymentOptions) (
 	}
 	return
 }
+
+// Iterate returns an iterator over all the payments of your account or
+// organization.
+//
+// See: https://docs.mollie.com/reference/v2/payments-api/list-payments
+func (ps *PaymentsService) Iterate(opts *ListPaymentOptions) *Iterator[*Payment] {
+	return newListIterator[*Payment](ps.client, func(from string) (string, interface{}) {
+		o := ListPaymentOptions{}
+		if opts != nil {
+			o = *opts
+		}
+
+		if from != "" {
+			o.From = from
+		}
+
+		return "v2/payments", &o
+	})
+}
@@ -2,6 +2,7 @@ package mollie
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -36,7 +37,7 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Get() {
 				context.Background(),
 				"tr_WDqYK6vllg",
 				&PaymentOptions{
-					Include: "settlements",
+					Include: PaymentIncludes{IncludeQRCode},
 				},
 			},
 			false,
@@ -45,7 +46,7 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Get() {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(ps.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
 				testMethod(ps.T(), r, "GET")
-				testQuery(ps.T(), r, "include=settlements&testmode=true")
+				testQuery(ps.T(), r, "include=details.qrCode&testmode=true")
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -225,7 +226,7 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Create() {
 					BillingEmail: "test@example.com",
 				},
 				&PaymentOptions{
-					Include: "settlements",
+					Include: PaymentIncludes{IncludeQRCode},
 				},
 			},
 			false,
@@ -236,7 +237,7 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Create() {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(ps.T(), r, AuthHeader, "Bearer access_example_token")
 				testMethod(ps.T(), r, "POST")
-				testQuery(ps.T(), r, "include=settlements&testmode=true")
+				testQuery(ps.T(), r, "include=details.qrCode&testmode=true")
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -252,7 +253,7 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Create() {
 					BillingEmail: "test@example.com",
 				},
 				&PaymentOptions{
-					Include: "settlements",
+					Include: PaymentIncludes{IncludeQRCode},
 				},
 			},
 			false,
@@ -261,7 +262,7 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Create() {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(ps.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
 				testMethod(ps.T(), r, "POST")
-				testQuery(ps.T(), r, "include=settlements&testmode=true")
+				testQuery(ps.T(), r, "include=details.qrCode&testmode=true")
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -269,6 +270,37 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Create() {
 				_, _ = w.Write([]byte(testdata.GetPaymentResponse))
 			},
 		},
+		{
+			"create point of sale payments works as expected.",
+			args{
+				context.Background(),
+				Payment{
+					Amount:      &Amount{Value: "10.00", Currency: "EUR"},
+					Description: "Order #12345",
+					Method:      PointOfSale,
+					TerminalID:  "term_7MgL4wea46qkRcoTZjWEH",
+				},
+				nil,
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(ps.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(ps.T(), r, "POST")
+				testQuery(ps.T(), r, "testmode=true")
+
+				var p Payment
+				_ = json.NewDecoder(r.Body).Decode(&p)
+				ps.Equal(PointOfSale, p.Method)
+				ps.Equal("term_7MgL4wea46qkRcoTZjWEH", p.TerminalID)
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				_, _ = w.Write([]byte(testdata.CreatePointOfSalePaymentResponse))
+			},
+		},
 		{
 			"create payments, an error is returned from the server",
 			args{
@@ -538,6 +570,29 @@ func (ps *paymentsServiceSuite) TestPaymentsService_Cancel() {
 	}
 }
 
+func (ps *paymentsServiceSuite) TestPaymentsService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ps.T(), r, "GET")
+
+		if r.URL.Query().Get("from") == "" {
+			testQuery(ps.T(), r, "limit=5&testmode=true")
+			_, _ = w.Write([]byte(testdata.ListPaymentsResponse))
+			return
+		}
+
+		testQuery(ps.T(), r, "from=tr_SDkzMggpvx&limit=5&testmode=true")
+		_, _ = w.Write([]byte(`{"count": 1, "_embedded": {"payments": [{"resource": "payment", "id": "tr_SDkzMggpvx"}]}, "_links": {"next": null}}`))
+	})
+
+	payments, err := tClient.Payments.Iterate(&ListPaymentOptions{Limit: 5}).All(context.Background())
+	ps.Nil(err)
+	ps.Equal("tr_7UhSN1zuXS", payments[0].ID)
+	ps.Equal("tr_SDkzMggpvx", payments[len(payments)-1].ID)
+}
+
 func TestPaymentsService(t *testing.T) {
 	suite.Run(t, new(paymentsServiceSuite))
 }
@@ -30,8 +30,14 @@ const (
 	ShipmentsWrite     PermissionGrant = "shipments.write"
 	OrganizationsRead  PermissionGrant = "organizations.read"
 	OrganizationsWrite PermissionGrant = "organizations.write"
-	OnboardingRead     PermissionGrant = "onbording.read"
-	OnboardingWrite    PermissionGrant = "onbording.write"
+	OnboardingRead     PermissionGrant = "onboarding.read"
+	OnboardingWrite    PermissionGrant = "onboarding.write"
+	BalancesRead       PermissionGrant = "balances.read"
+	SettlementsRead    PermissionGrant = "settlements.read"
+	PaymentLinksRead   PermissionGrant = "payment-links.read"
+	PaymentLinksWrite  PermissionGrant = "payment-links.write"
+	TerminalsRead      PermissionGrant = "terminals.read"
+	TerminalsWrite     PermissionGrant = "terminals.write"
 )
 
 // Permission represents an action that
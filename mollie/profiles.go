@@ -35,6 +35,8 @@ type Profile struct {
 	Status  ProfileStatus `json:"status,omitempty"`
 	Website string        `json:"website,omitempty"`
 	Links   ProfileLinks  `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // ProfileLinks contains URL's to relevant information related to
@@ -105,6 +107,10 @@ func (ps *ProfilesService) get(ctx context.Context, id string) (res *Response, p
 
 // Create stores a new profile in your Mollie account.
 func (ps *ProfilesService) Create(ctx context.Context, np *Profile) (res *Response, p *Profile, err error) {
+	if err = ps.client.validate(np); err != nil {
+		return
+	}
+
 	res, err = ps.client.post(ctx, "v2/profiles", np, nil)
 	if err != nil {
 		return
@@ -118,6 +124,10 @@ func (ps *ProfilesService) Create(ctx context.Context, np *Profile) (res *Respon
 
 // Update allows you to perform mutations on a profile.
 func (ps *ProfilesService) Update(ctx context.Context, id string, up *Profile) (res *Response, p *Profile, err error) {
+	if err = ps.client.validate(up); err != nil {
+		return
+	}
+
 	res, err = ps.client.patch(ctx, fmt.Sprintf("v2/profiles/%s", id), up, nil)
 	if err != nil {
 		return
@@ -0,0 +1,241 @@
+package mollie
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// rawJSON keeps the JSON a resource was decoded from so fields added by
+// Mollie after a release of this package can still be read. It is
+// embedded as a pointer in the resources returned by the API so they
+// stay comparable, the typed decoding is not affected.
+type rawJSON struct {
+	data json.RawMessage
+	typ  reflect.Type
+}
+
+// knownFields caches the lower cased JSON names of the fields of every
+// resource type, encoding/json matches keys case insensitively.
+var knownFields sync.Map
+
+// decodeWithRaw decodes b into v and keeps it in raw. v must point to a
+// type without the UnmarshalJSON method of the resource, e.g.
+//
+//	type payment Payment
+//	return decodeWithRaw(b, (*payment)(p), &p.rawJSON)
+func decodeWithRaw[T any](b []byte, v *T, raw **rawJSON) error {
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+
+	*raw = nil
+	if !bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		*raw = &rawJSON{
+			data: append(json.RawMessage(nil), b...),
+			typ:  reflect.TypeOf(v).Elem(),
+		}
+	}
+
+	return nil
+}
+
+// Raw returns the JSON the resource was decoded from, nil when it was
+// not decoded from a response.
+func (r *rawJSON) Raw() json.RawMessage {
+	if r == nil {
+		return nil
+	}
+
+	return r.data
+}
+
+// Extra returns the raw JSON of a field, nil when it is missing. Nested
+// fields are separated by dots, e.g. "details.cardLabel".
+//
+// It is meant for the fields this version of the package doesn't decode
+// yet, see UnknownFields.
+func (r *rawJSON) Extra(field string) json.RawMessage {
+	if r == nil {
+		return nil
+	}
+
+	value := r.data
+
+	for _, name := range strings.Split(field, ".") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return nil
+		}
+
+		v, ok := fields[name]
+		if !ok {
+			return nil
+		}
+
+		value = v
+	}
+
+	return value
+}
+
+// UnknownFields returns the sorted names of the top level fields of the
+// raw JSON that are not decoded into the resource.
+func (r *rawJSON) UnknownFields() []string {
+	var fields map[string]json.RawMessage
+	if r == nil || json.Unmarshal(r.data, &fields) != nil {
+		return nil
+	}
+
+	known := fieldNames(r.typ)
+
+	var unknown []string
+
+	for name := range fields {
+		if !known[strings.ToLower(name)] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+func fieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := map[string]bool{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+
+		names[strings.ToLower(name)] = true
+	}
+
+	knownFields.Store(t, names)
+
+	return names
+}
+
+// UnmarshalJSON decodes a payment keeping its raw JSON.
+func (p *Payment) UnmarshalJSON(b []byte) error {
+	type payment Payment
+
+	return decodeWithRaw(b, (*payment)(p), &p.rawJSON)
+}
+
+// UnmarshalJSON decodes an order keeping its raw JSON.
+func (o *Order) UnmarshalJSON(b []byte) error {
+	type order Order
+
+	return decodeWithRaw(b, (*order)(o), &o.rawJSON)
+}
+
+// UnmarshalJSON decodes a refund keeping its raw JSON.
+func (r *Refund) UnmarshalJSON(b []byte) error {
+	type refund Refund
+
+	return decodeWithRaw(b, (*refund)(r), &r.rawJSON)
+}
+
+// UnmarshalJSON decodes a chargeback keeping its raw JSON.
+func (c *Chargeback) UnmarshalJSON(b []byte) error {
+	type chargeback Chargeback
+
+	return decodeWithRaw(b, (*chargeback)(c), &c.rawJSON)
+}
+
+// UnmarshalJSON decodes a capture keeping its raw JSON.
+func (c *Capture) UnmarshalJSON(b []byte) error {
+	type capture Capture
+
+	return decodeWithRaw(b, (*capture)(c), &c.rawJSON)
+}
+
+// UnmarshalJSON decodes a customer keeping its raw JSON.
+func (c *Customer) UnmarshalJSON(b []byte) error {
+	type customer Customer
+
+	return decodeWithRaw(b, (*customer)(c), &c.rawJSON)
+}
+
+// UnmarshalJSON decodes a mandate keeping its raw JSON.
+func (m *Mandate) UnmarshalJSON(b []byte) error {
+	type mandate Mandate
+
+	return decodeWithRaw(b, (*mandate)(m), &m.rawJSON)
+}
+
+// UnmarshalJSON decodes a subscription keeping its raw JSON.
+func (s *Subscription) UnmarshalJSON(b []byte) error {
+	type subscription Subscription
+
+	return decodeWithRaw(b, (*subscription)(s), &s.rawJSON)
+}
+
+// UnmarshalJSON decodes a settlement keeping its raw JSON.
+func (s *Settlement) UnmarshalJSON(b []byte) error {
+	type settlement Settlement
+
+	return decodeWithRaw(b, (*settlement)(s), &s.rawJSON)
+}
+
+// UnmarshalJSON decodes a shipment keeping its raw JSON.
+func (s *Shipment) UnmarshalJSON(b []byte) error {
+	type shipment Shipment
+
+	return decodeWithRaw(b, (*shipment)(s), &s.rawJSON)
+}
+
+// UnmarshalJSON decodes a payment link keeping its raw JSON.
+func (pl *PaymentLink) UnmarshalJSON(b []byte) error {
+	type paymentLink PaymentLink
+
+	return decodeWithRaw(b, (*paymentLink)(pl), &pl.rawJSON)
+}
+
+// UnmarshalJSON decodes a profile keeping its raw JSON.
+func (p *Profile) UnmarshalJSON(b []byte) error {
+	type profile Profile
+
+	return decodeWithRaw(b, (*profile)(p), &p.rawJSON)
+}
+
+// UnmarshalJSON decodes an invoice keeping its raw JSON.
+func (i *Invoice) UnmarshalJSON(b []byte) error {
+	type invoice Invoice
+
+	return decodeWithRaw(b, (*invoice)(i), &i.rawJSON)
+}
+
+// UnmarshalJSON decodes an organization keeping its raw JSON.
+func (o *Organization) UnmarshalJSON(b []byte) error {
+	type organization Organization
+
+	return decodeWithRaw(b, (*organization)(o), &o.rawJSON)
+}
+
+// UnmarshalJSON decodes a terminal keeping its raw JSON.
+func (t *Terminal) UnmarshalJSON(b []byte) error {
+	type terminal Terminal
+
+	return decodeWithRaw(b, (*terminal)(t), &t.rawJSON)
+}
@@ -0,0 +1,125 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawJSON(t *testing.T) {
+	body := `{
+		"resource": "payment",
+		"id": "tr_WDqYK6vllg",
+		"Status": "paid",
+		"issuerCountry": "NL",
+		"details": {"cardLabel": "Visa", "cardBin": "424242"},
+		"_links": {}
+	}`
+
+	var p Payment
+	require.Nil(t, json.Unmarshal([]byte(body), &p))
+
+	assert.Equal(t, "tr_WDqYK6vllg", p.ID)
+	assert.Equal(t, PaymentPaid, p.Status)
+	assert.JSONEq(t, body, string(p.Raw()))
+
+	assert.Equal(t, []string{"issuerCountry"}, p.UnknownFields())
+	assert.Equal(t, json.RawMessage(`"NL"`), p.Extra("issuerCountry"))
+	assert.Equal(t, json.RawMessage(`"424242"`), p.Extra("details.cardBin"))
+	assert.Nil(t, p.Extra("details.cardBin.value"))
+	assert.Nil(t, p.Extra("missing"))
+
+	b, err := json.Marshal(p)
+	require.Nil(t, err)
+	assert.NotContains(t, string(b), "issuerCountry")
+}
+
+func TestRawJSON_NotDecoded(t *testing.T) {
+	p := Payment{ID: "tr_WDqYK6vllg"}
+
+	assert.Nil(t, p.Raw())
+	assert.Nil(t, p.Extra("id"))
+	assert.Nil(t, p.UnknownFields())
+
+	var w struct {
+		Payment Payment `json:"payment"`
+	}
+	require.Nil(t, json.Unmarshal([]byte(`{"payment": null}`), &w))
+	assert.Nil(t, w.Payment.Raw())
+}
+
+func TestRawJSON_Resources(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		v    interface {
+			Raw() json.RawMessage
+			UnknownFields() []string
+		}
+	}{
+		{"payment", testdata.GetPaymentResponse, &Payment{}},
+		{"order", testdata.GetOrderResponse, &Order{}},
+		{"refund", testdata.GetRefundResponse, &Refund{}},
+		{"chargeback", testdata.GetChargebackResponse, &Chargeback{}},
+		{"capture", testdata.GetCaptureResponse, &Capture{}},
+		{"customer", testdata.GetCustomerResponse, &Customer{}},
+		{"mandate", testdata.GetMandateResponse, &Mandate{}},
+		{"subscription", testdata.GetSubscriptionResponse, &Subscription{}},
+		{"settlement", testdata.GetSettlementsResponse, &Settlement{}},
+		{"shipment", testdata.GetShipmentsResponse, &Shipment{}},
+		{"payment link", testdata.GetPaymentLinkResponse, &PaymentLink{}},
+		{"profile", testdata.GetProfileResponse, &Profile{}},
+		{"invoice", testdata.GetInvoiceResponse, &Invoice{}},
+		{"organization", testdata.GetOrganizationResponse, &Organization{}},
+		{"terminal", testdata.GetTerminalResponse, &Terminal{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Nil(t, json.Unmarshal([]byte(c.body), c.v))
+			assert.JSONEq(t, c.body, string(c.v.Raw()))
+		})
+	}
+}
+
+func TestRawJSON_Service(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{
+			"count": 1,
+			"_embedded": {"payments": [{"resource": "payment", "id": "tr_7UhSN1zuXS", "newField": true}]}
+		}`)
+	})
+
+	_, pl, err := tClient.Payments.List(context.Background(), nil)
+	require.Nil(t, err)
+	require.Len(t, pl.Embedded.Payments, 1)
+
+	p := pl.Embedded.Payments[0]
+	assert.Equal(t, "tr_7UhSN1zuXS", p.ID)
+	assert.Equal(t, []string{"newField"}, p.UnknownFields())
+	assert.Equal(t, json.RawMessage(`true`), p.Extra("newField"))
+}
+
+func TestRawJSON_Comparable(t *testing.T) {
+	var m Mandate
+	require.Nil(t, json.Unmarshal([]byte(testdata.GetMandateResponse), &m))
+
+	copied := m
+	assert.True(t, m == copied)
+	assert.True(t, Organization{} == Organization{})
+	assert.True(t, Terminal{} == Terminal{})
+	assert.True(t, Capture{} == Capture{})
+	assert.True(t, Profile{} == Profile{})
+}
@@ -0,0 +1,536 @@
+// Package reconcile checks that a Mollie settlement adds up.
+//
+// A Statement holds a settlement together with every payment, refund,
+// chargeback and capture it contains. Reconcile compares the settled
+// transactions with the revenue and costs breakdown of the settlement
+// periods and with the paid out amount, reporting every difference.
+//
+//	st, err := reconcile.Load(ctx, client, "stl_jDk30akdN")
+//	if err != nil {
+//		return err
+//	}
+//
+//	result := reconcile.Reconcile(st)
+//	if !result.Balanced() {
+//		for _, d := range result.Discrepancies {
+//			log.Println(d.Kind, d.Period, d.Method, d.Difference.Value)
+//		}
+//	}
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// Methods used by the settlement periods to group the transactions
+// deducted from the revenue.
+const (
+	RefundMethod     mollie.PaymentMethod = "refund"
+	ChargebackMethod mollie.PaymentMethod = "chargeback"
+)
+
+// Mollie books transactions using the Amsterdam time zone.
+var bookingLocation = loadLocation("Europe/Amsterdam")
+
+func loadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Statement is a settlement together with all the transactions it contains.
+type Statement struct {
+	Settlement  *mollie.Settlement
+	Payments    []*mollie.Payment
+	Refunds     []*mollie.Refund
+	Chargebacks []*mollie.Chargeback
+	Captures    []*mollie.Capture
+}
+
+// TransactionType describes the resource a transaction comes from.
+type TransactionType string
+
+// Settled transaction types, refunds and chargebacks match the methods
+// used for them in the settlement periods.
+const (
+	PaymentTransaction    TransactionType = "payment"
+	CaptureTransaction    TransactionType = "capture"
+	RefundTransaction     TransactionType = TransactionType(RefundMethod)
+	ChargebackTransaction TransactionType = TransactionType(ChargebackMethod)
+)
+
+// Transaction is a single movement of a settlement. SettlementAmount is
+// positive for payments and captures and negative for refunds and
+// chargebacks, regardless of the sign used by the API.
+type Transaction struct {
+	Type             TransactionType
+	ID               string
+	PaymentID        string
+	Method           mollie.PaymentMethod
+	Description      string
+	BookedAt         time.Time
+	Amount           *mollie.Amount
+	SettlementAmount *mollie.Amount
+}
+
+// Transactions flattens the statement in a single list sorted by booking
+// date. Payments settled through their captures are left out so each
+// movement is only listed once, refunds and captures use the method of
+// their payment when it is part of the statement.
+func (st *Statement) Transactions() []Transaction {
+	payments := make(map[string]*mollie.Payment, len(st.Payments))
+	captured := make(map[string]bool, len(st.Captures))
+
+	for _, p := range st.Payments {
+		payments[p.ID] = p
+	}
+
+	for _, c := range st.Captures {
+		captured[c.PaymentID] = true
+	}
+
+	method := func(id string) mollie.PaymentMethod {
+		if p, ok := payments[id]; ok {
+			return p.Method
+		}
+
+		return ""
+	}
+
+	var txs []Transaction
+
+	for _, p := range st.Payments {
+		// authorized payments are settled through their captures.
+		if captured[p.ID] {
+			continue
+		}
+
+		txs = append(txs, Transaction{
+			Type:             PaymentTransaction,
+			ID:               p.ID,
+			PaymentID:        p.ID,
+			Method:           p.Method,
+			Description:      p.Description,
+			BookedAt:         booked(p.PaidAt, p.CreatedAt),
+			Amount:           p.Amount,
+			SettlementAmount: signed(p.SettlementAmount, 1),
+		})
+	}
+
+	for _, c := range st.Captures {
+		txs = append(txs, Transaction{
+			Type:             CaptureTransaction,
+			ID:               c.ID,
+			PaymentID:        c.PaymentID,
+			Method:           method(c.PaymentID),
+			BookedAt:         booked(c.CreatedAt),
+			Amount:           c.Amount,
+			SettlementAmount: signed(c.SettlementAmount, 1),
+		})
+	}
+
+	for _, rf := range st.Refunds {
+		txs = append(txs, Transaction{
+			Type:             RefundTransaction,
+			ID:               rf.ID,
+			PaymentID:        rf.PaymentID,
+			Method:           method(rf.PaymentID),
+			Description:      rf.Description,
+			BookedAt:         booked(rf.CreatedAt),
+			Amount:           rf.Amount,
+			SettlementAmount: signed(rf.SettlementAmount, -1),
+		})
+	}
+
+	for _, cb := range st.Chargebacks {
+		txs = append(txs, Transaction{
+			Type:             ChargebackTransaction,
+			ID:               cb.ID,
+			PaymentID:        cb.PaymentID,
+			Method:           method(cb.PaymentID),
+			BookedAt:         booked(cb.CreatedAt),
+			Amount:           cb.Amount,
+			SettlementAmount: signed(cb.SettlementAmount, -1),
+		})
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].BookedAt.Before(txs[j].BookedAt)
+	})
+
+	return txs
+}
+
+// signed returns a copy of a with the given sign, amounts that can't be
+// parsed are returned as they are.
+func signed(a *mollie.Amount, sign int) *mollie.Amount {
+	if a == nil {
+		return nil
+	}
+
+	v, err := a.Rat()
+	if err != nil || v.Sign() == 0 || v.Sign() == sign {
+		return a
+	}
+
+	value := strings.TrimPrefix(a.Value, "-")
+	if sign < 0 {
+		value = "-" + value
+	}
+
+	return &mollie.Amount{Currency: a.Currency, Value: value}
+}
+
+// Load retrieves a settlement and all the pages of its payments,
+// refunds, chargebacks and captures.
+func Load(ctx context.Context, client *mollie.Client, id string) (st *Statement, err error) {
+	st = &Statement{}
+
+	if _, st.Settlement, err = client.Settlements.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("reconcile_error: settlement: %w", err)
+	}
+
+	// the settlement can be requested by its bank reference.
+	id = st.Settlement.ID
+
+	if st.Payments, err = client.Settlements.IteratePayments(id, nil).All(ctx); err != nil {
+		return nil, fmt.Errorf("reconcile_error: payments: %w", err)
+	}
+
+	if st.Refunds, err = client.Settlements.IterateRefunds(id, nil).All(ctx); err != nil {
+		return nil, fmt.Errorf("reconcile_error: refunds: %w", err)
+	}
+
+	if st.Chargebacks, err = client.Settlements.IterateChargebacks(id, nil).All(ctx); err != nil {
+		return nil, fmt.Errorf("reconcile_error: chargebacks: %w", err)
+	}
+
+	if st.Captures, err = client.Settlements.IterateCaptures(id, nil).All(ctx); err != nil {
+		return nil, fmt.Errorf("reconcile_error: captures: %w", err)
+	}
+
+	return st, nil
+}
+
+// Kind describes the type of a discrepancy.
+type Kind string
+
+// Reported discrepancy kinds.
+const (
+	// TotalMismatch compares the paid out amount with the settled
+	// transactions minus the costs.
+	TotalMismatch Kind = "total"
+	// PeriodsMismatch compares the paid out amount with the revenue
+	// minus the costs of all the periods.
+	PeriodsMismatch Kind = "periods"
+	// AmountMismatch compares the revenue of a method in a period with
+	// its settled transactions.
+	AmountMismatch Kind = "amount"
+	// CountMismatch compares the number of transactions of a method in
+	// a period with the count reported by its revenue.
+	CountMismatch Kind = "count"
+	// CurrencyMismatch flags amounts not using the settlement currency.
+	CurrencyMismatch Kind = "currency"
+	// InvalidAmount flags amounts whose value is not a decimal number.
+	InvalidAmount Kind = "invalid_amount"
+)
+
+// Discrepancy is a difference between two values that should match.
+// Period and Method are empty for the settlement wide kinds.
+type Discrepancy struct {
+	Kind       Kind                 `json:"kind"`
+	Period     string               `json:"period,omitempty"`
+	Method     mollie.PaymentMethod `json:"method,omitempty"`
+	Expected   *mollie.Amount       `json:"expected,omitempty"`
+	Actual     *mollie.Amount       `json:"actual,omitempty"`
+	Difference *mollie.Amount       `json:"difference,omitempty"`
+	Detail     string               `json:"detail"`
+}
+
+// MethodTotal compares the revenue reported for a method in a period,
+// formatted as YYYY-MM, with the transactions settled for it. Refunds
+// and chargebacks are grouped as RefundMethod and ChargebackMethod and
+// their amounts are positive, as they are shown in the periods.
+type MethodTotal struct {
+	Period           string               `json:"period"`
+	Method           mollie.PaymentMethod `json:"method"`
+	Revenue          *mollie.Amount       `json:"revenue"`
+	RevenueCount     int                  `json:"revenueCount"`
+	Transactions     *mollie.Amount       `json:"transactions"`
+	TransactionCount int                  `json:"transactionCount"`
+	Costs            *mollie.Amount       `json:"costs"`
+}
+
+// Result is the outcome of reconciling a settlement.
+type Result struct {
+	SettlementID  string         `json:"settlementId"`
+	Reference     string         `json:"reference,omitempty"`
+	Amount        *mollie.Amount `json:"amount"`
+	Transactions  *mollie.Amount `json:"transactions"`
+	Revenue       *mollie.Amount `json:"revenue"`
+	Costs         *mollie.Amount `json:"costs"`
+	ExpectedNet   *mollie.Amount `json:"expectedNet"`
+	Methods       []MethodTotal  `json:"methods"`
+	Discrepancies []Discrepancy  `json:"discrepancies"`
+}
+
+// Balanced reports whether no discrepancies were found.
+func (r *Result) Balanced() bool {
+	return len(r.Discrepancies) == 0
+}
+
+type bucketKey struct {
+	period string
+	method mollie.PaymentMethod
+}
+
+type bucket struct {
+	revenue, transactions, costs *big.Rat
+	revenueCount, count          int
+}
+
+type reconciler struct {
+	currency      string
+	buckets       map[bucketKey]*bucket
+	discrepancies []Discrepancy
+}
+
+// Reconcile computes the expected net of a statement, the settled
+// transactions minus the settlement costs, and compares it with the
+// settlement amount and with the breakdown of its periods.
+//
+// Transactions are assigned to the period of the date they were paid or
+// created on in the Amsterdam time zone. Values are compared after
+// rounding them to the decimals of the settlement currency.
+func Reconcile(st *Statement) *Result {
+	s := st.Settlement
+
+	r := &reconciler{buckets: map[bucketKey]*bucket{}}
+	if s.Amount != nil {
+		r.currency = s.Amount.Currency
+	}
+
+	for _, t := range st.Transactions() {
+		method := t.Method
+		if t.Type == RefundTransaction || t.Type == ChargebackTransaction {
+			method = mollie.PaymentMethod(t.Type)
+		}
+
+		r.transaction(t.ID, method, t.BookedAt, t.SettlementAmount)
+	}
+
+	r.periods(s.Periods)
+
+	return r.result(s)
+}
+
+func (r *reconciler) bucket(period string, method mollie.PaymentMethod) *bucket {
+	k := bucketKey{period, method}
+
+	b, ok := r.buckets[k]
+	if !ok {
+		b = &bucket{revenue: new(big.Rat), transactions: new(big.Rat), costs: new(big.Rat)}
+		r.buckets[k] = b
+	}
+
+	return b
+}
+
+func (r *reconciler) transaction(id string, method mollie.PaymentMethod, at time.Time, amount *mollie.Amount) {
+	b := r.bucket(at.In(bookingLocation).Format("2006-01"), method)
+	b.count++
+
+	if v, ok := r.value(id, amount); ok {
+		b.transactions.Add(b.transactions, v.Abs(v))
+	}
+}
+
+// periods adds the revenue and costs of every period to the buckets.
+func (r *reconciler) periods(periods mollie.SettlementObject) {
+	for year, months := range periods {
+		for month, p := range months {
+			period := year + "-" + month
+			if y, err := strconv.Atoi(year); err == nil {
+				if m, err := strconv.Atoi(month); err == nil {
+					period = fmt.Sprintf("%04d-%02d", y, m)
+				}
+			}
+
+			for _, rv := range p.Revenue {
+				b := r.bucket(period, rv.Method)
+				b.revenueCount += rv.Count
+
+				if v, ok := r.value(rv.Description, rv.AmountGross); ok {
+					b.revenue.Add(b.revenue, v.Abs(v))
+				}
+			}
+
+			for _, c := range p.Costs {
+				b := r.bucket(period, c.Method)
+
+				if v, ok := r.value(c.Description, c.AmountGross); ok {
+					b.costs.Add(b.costs, v)
+				}
+			}
+		}
+	}
+}
+
+// value parses an amount, recording a discrepancy when it can't be
+// used to reconcile the settlement.
+func (r *reconciler) value(source string, a *mollie.Amount) (*big.Rat, bool) {
+	if a == nil {
+		return nil, false
+	}
+
+	if r.currency != "" && a.Currency != r.currency {
+		r.discrepancies = append(r.discrepancies, Discrepancy{
+			Kind:   CurrencyMismatch,
+			Actual: a,
+			Detail: fmt.Sprintf("%s uses %s instead of %s", source, a.Currency, r.currency),
+		})
+
+		return nil, false
+	}
+
+	v, err := a.Rat()
+	if err != nil {
+		r.discrepancies = append(r.discrepancies, Discrepancy{
+			Kind:   InvalidAmount,
+			Actual: a,
+			Detail: fmt.Sprintf("%s has an invalid value: %v", source, err),
+		})
+
+		return nil, false
+	}
+
+	return v, true
+}
+
+func (r *reconciler) result(s *mollie.Settlement) *Result {
+	var (
+		transactions = new(big.Rat)
+		revenue      = new(big.Rat)
+		costs        = new(big.Rat)
+		keys         = make([]bucketKey, 0, len(r.buckets))
+	)
+
+	for k := range r.buckets {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].period != keys[j].period {
+			return keys[i].period < keys[j].period
+		}
+
+		return keys[i].method < keys[j].method
+	})
+
+	res := &Result{
+		SettlementID: s.ID,
+		Reference:    s.Reference,
+		Amount:       s.Amount,
+	}
+
+	for _, k := range keys {
+		b := r.buckets[k]
+
+		sign := big.NewRat(1, 1)
+		if k.method == RefundMethod || k.method == ChargebackMethod {
+			sign.Neg(sign)
+		}
+
+		transactions.Add(transactions, new(big.Rat).Mul(sign, b.transactions))
+		revenue.Add(revenue, new(big.Rat).Mul(sign, b.revenue))
+		costs.Add(costs, b.costs)
+
+		res.Methods = append(res.Methods, MethodTotal{
+			Period:           k.period,
+			Method:           k.method,
+			Revenue:          r.amount(b.revenue),
+			RevenueCount:     b.revenueCount,
+			Transactions:     r.amount(b.transactions),
+			TransactionCount: b.count,
+			Costs:            r.amount(b.costs),
+		})
+
+		if !r.equal(b.revenue, b.transactions) {
+			r.mismatch(AmountMismatch, k, b.revenue, b.transactions, "settled transactions differ from the period revenue")
+		}
+
+		if b.revenueCount != b.count {
+			r.discrepancies = append(r.discrepancies, Discrepancy{
+				Kind:   CountMismatch,
+				Period: k.period,
+				Method: k.method,
+				Detail: fmt.Sprintf("%d transactions were settled but the period revenue counts %d", b.count, b.revenueCount),
+			})
+		}
+	}
+
+	expected := new(big.Rat).Sub(transactions, costs)
+
+	res.Transactions = r.amount(transactions)
+	res.Revenue = r.amount(revenue)
+	res.Costs = r.amount(costs)
+	res.ExpectedNet = r.amount(expected)
+
+	if paid, err := s.Amount.Rat(); err == nil {
+		if !r.equal(expected, paid) {
+			r.mismatch(TotalMismatch, bucketKey{}, expected, paid, "the settlement amount differs from the transactions minus the costs")
+		}
+
+		if periods := new(big.Rat).Sub(revenue, costs); !r.equal(periods, paid) {
+			r.mismatch(PeriodsMismatch, bucketKey{}, periods, paid, "the settlement amount differs from the revenue minus the costs of its periods")
+		}
+	}
+
+	res.Discrepancies = r.discrepancies
+
+	return res
+}
+
+func (r *reconciler) mismatch(kind Kind, k bucketKey, expected, actual *big.Rat, detail string) {
+	r.discrepancies = append(r.discrepancies, Discrepancy{
+		Kind:       kind,
+		Period:     k.period,
+		Method:     k.method,
+		Expected:   r.amount(expected),
+		Actual:     r.amount(actual),
+		Difference: r.amount(new(big.Rat).Sub(actual, expected)),
+		Detail:     detail,
+	})
+}
+
+func (r *reconciler) amount(v *big.Rat) *mollie.Amount {
+	return mollie.AmountFromRat(r.currency, v)
+}
+
+// equal compares two values rounded to the decimals of the currency.
+func (r *reconciler) equal(a, b *big.Rat) bool {
+	return r.amount(a).Value == r.amount(b).Value
+}
+
+// booked returns the first known date, transactions without dates are
+// assigned to the zero period.
+func booked(dates ...*time.Time) time.Time {
+	for _, d := range dates {
+		if d != nil {
+			return *d
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,187 @@
+package reconcile
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func settlementMux() *http.ServeMux {
+	write := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/settlements/1234567.1804.03", write(testdata.GetSettlementsResponse))
+	mux.HandleFunc("/v2/settlements/stl_jDk30akdN/payments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") == "tr_BXjMNJpjEb" {
+			_, _ = w.Write([]byte(testdata.ListSettlementPaymentsLastPageResponse))
+			return
+		}
+
+		_, _ = w.Write([]byte(testdata.ListSettlementPaymentsResponse))
+	})
+	mux.HandleFunc("/v2/settlements/stl_jDk30akdN/refunds", write(testdata.ListSettlementRefundsResponse))
+	mux.HandleFunc("/v2/settlements/stl_jDk30akdN/chargebacks", write(testdata.ListSettlementChargebacksResponse))
+	mux.HandleFunc("/v2/settlements/stl_jDk30akdN/captures", write(testdata.ListSettlementCapturesResponse))
+
+	return mux
+}
+
+func TestLoad(t *testing.T) {
+	st, err := Load(context.Background(), mollietest.NewClient(t, settlementMux()), "1234567.1804.03")
+	require.Nil(t, err)
+
+	assert.Equal(t, "stl_jDk30akdN", st.Settlement.ID)
+	assert.Len(t, st.Payments, 6)
+	assert.Len(t, st.Refunds, 2)
+	assert.Empty(t, st.Chargebacks)
+	assert.Empty(t, st.Captures)
+}
+
+func TestLoad_Error(t *testing.T) {
+	_, err := Load(context.Background(), mollietest.NewClient(t, http.NewServeMux()), "stl_missing")
+	assert.ErrorContains(t, err, "reconcile_error: settlement")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/settlements/stl_jDk30akdN", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.GetSettlementsResponse))
+	})
+
+	_, err = Load(context.Background(), mollietest.NewClient(t, mux), "stl_jDk30akdN")
+	assert.ErrorContains(t, err, "reconcile_error: payments")
+}
+
+func TestReconcile_Balanced(t *testing.T) {
+	st, err := Load(context.Background(), mollietest.NewClient(t, settlementMux()), "1234567.1804.03")
+	require.Nil(t, err)
+
+	res := Reconcile(st)
+
+	assert.True(t, res.Balanced(), res.Discrepancies)
+	assert.Equal(t, "stl_jDk30akdN", res.SettlementID)
+	assert.Equal(t, "42.90", res.Transactions.Value)
+	assert.Equal(t, "42.90", res.Revenue.Value)
+	assert.Equal(t, "3.15", res.Costs.Value)
+	assert.Equal(t, "39.75", res.ExpectedNet.Value)
+
+	assert.Equal(t, []MethodTotal{
+		{
+			Period:           "2018-04",
+			Method:           mollie.IDeal,
+			Revenue:          &mollie.Amount{Currency: "EUR", Value: "86.10"},
+			RevenueCount:     6,
+			Transactions:     &mollie.Amount{Currency: "EUR", Value: "86.10"},
+			TransactionCount: 6,
+			Costs:            &mollie.Amount{Currency: "EUR", Value: "2.54"},
+		},
+		{
+			Period:           "2018-04",
+			Method:           RefundMethod,
+			Revenue:          &mollie.Amount{Currency: "EUR", Value: "43.20"},
+			RevenueCount:     2,
+			Transactions:     &mollie.Amount{Currency: "EUR", Value: "43.20"},
+			TransactionCount: 2,
+			Costs:            &mollie.Amount{Currency: "EUR", Value: "0.61"},
+		},
+	}, res.Methods)
+}
+
+func TestReconcile_Discrepancies(t *testing.T) {
+	st, err := Load(context.Background(), mollietest.NewClient(t, settlementMux()), "1234567.1804.03")
+	require.Nil(t, err)
+
+	// a refund missing from the settlement transactions.
+	st.Refunds = st.Refunds[:1]
+
+	res := Reconcile(st)
+	require.False(t, res.Balanced())
+
+	kinds := make(map[Kind]Discrepancy)
+	for _, d := range res.Discrepancies {
+		kinds[d.Kind] = d
+	}
+
+	assert.Len(t, res.Discrepancies, 3)
+
+	amount := kinds[AmountMismatch]
+	assert.Equal(t, "2018-04", amount.Period)
+	assert.Equal(t, RefundMethod, amount.Method)
+	assert.Equal(t, "43.20", amount.Expected.Value)
+	assert.Equal(t, "20.00", amount.Actual.Value)
+	assert.Equal(t, "-23.20", amount.Difference.Value)
+
+	assert.Equal(t, RefundMethod, kinds[CountMismatch].Method)
+
+	total := kinds[TotalMismatch]
+	assert.Equal(t, "62.95", total.Expected.Value)
+	assert.Equal(t, "39.75", total.Actual.Value)
+}
+
+func TestReconcile_Currency(t *testing.T) {
+	st, err := Load(context.Background(), mollietest.NewClient(t, settlementMux()), "1234567.1804.03")
+	require.Nil(t, err)
+
+	st.Payments[0].SettlementAmount = &mollie.Amount{Currency: "USD", Value: "10.00"}
+	st.Payments[1].SettlementAmount = &mollie.Amount{Currency: "EUR", Value: "ten"}
+
+	res := Reconcile(st)
+
+	var kinds []Kind
+	for _, d := range res.Discrepancies {
+		kinds = append(kinds, d.Kind)
+	}
+
+	assert.Contains(t, kinds, CurrencyMismatch)
+	assert.Contains(t, kinds, InvalidAmount)
+	assert.Contains(t, kinds, AmountMismatch)
+}
+
+func TestStatement_Transactions(t *testing.T) {
+	paid := func(d int) *time.Time {
+		t := time.Date(2018, 4, d, 10, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	st := &Statement{
+		Payments: []*mollie.Payment{
+			{ID: "tr_paid", Method: mollie.IDeal, PaidAt: paid(2), SettlementAmount: &mollie.Amount{Currency: "EUR", Value: "10.00"}},
+			{ID: "tr_captured", Method: mollie.KlarnaPayLater, PaidAt: paid(1)},
+		},
+		Captures: []*mollie.Capture{
+			{ID: "cpt_1", PaymentID: "tr_captured", CreatedAt: paid(3), SettlementAmount: &mollie.Amount{Currency: "EUR", Value: "30.00"}},
+		},
+		Refunds: []*mollie.Refund{
+			{ID: "re_1", PaymentID: "tr_paid", CreatedAt: paid(4), SettlementAmount: &mollie.Amount{Currency: "EUR", Value: "-5.00"}},
+		},
+		Chargebacks: []*mollie.Chargeback{
+			{ID: "chb_1", PaymentID: "tr_other", CreatedAt: paid(1), SettlementAmount: &mollie.Amount{Currency: "EUR", Value: "2.00"}},
+		},
+	}
+
+	txs := st.Transactions()
+	require.Len(t, txs, 4)
+
+	assert.Equal(t, ChargebackTransaction, txs[0].Type)
+	assert.Equal(t, "-2.00", txs[0].SettlementAmount.Value)
+	assert.Equal(t, mollie.PaymentMethod(""), txs[0].Method)
+
+	assert.Equal(t, "tr_paid", txs[1].ID)
+	assert.Equal(t, "10.00", txs[1].SettlementAmount.Value)
+
+	assert.Equal(t, CaptureTransaction, txs[2].Type)
+	assert.Equal(t, mollie.KlarnaPayLater, txs[2].Method)
+
+	assert.Equal(t, RefundTransaction, txs[3].Type)
+	assert.Equal(t, mollie.IDeal, txs[3].Method)
+	assert.Equal(t, "-5.00", txs[3].SettlementAmount.Value)
+}
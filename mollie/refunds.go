@@ -3,26 +3,33 @@ package mollie
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"time"
 )
 
+var errRefundNotFound = errors.New("no refund exists with this ID")
+
 // Refund describe a refund for a certain payment.
 type Refund struct {
-	Resource         string       `json:"resource,omitempty"`
-	ID               string       `json:"id,omitempty"`
-	Amount           *Amount      `json:"amount,omitempty"`
-	SettlementID     string       `json:"settlementId,omitempty"`
-	SettlementAmount *Amount      `json:"settlementAmount,omitempty"`
-	Description      string       `json:"description,omitempty"`
-	Metadata         interface{}  `json:"metadata,omitempty"`
-	Status           RefundStatus `json:"status,omitempty"`
-	Lines            []*OrderLine `json:"lines,omitempty"`
-	PaymentID        string       `json:"paymentId,omitempty"`
-	OrderID          string       `json:"orderId,omitempty"`
-	CreatedAt        *time.Time   `json:"createdAt,omitempty"`
-	TestMode         bool         `json:"testmode,omitempty"`
-	Links            RefundLinks  `json:"_links,omitempty"`
+	Resource         string          `json:"resource,omitempty"`
+	ID               string          `json:"id,omitempty"`
+	Amount           *Amount         `json:"amount,omitempty"`
+	SettlementID     string          `json:"settlementId,omitempty"`
+	SettlementAmount *Amount         `json:"settlementAmount,omitempty"`
+	Description      string          `json:"description,omitempty"`
+	Metadata         interface{}     `json:"metadata,omitempty"`
+	Status           RefundStatus    `json:"status,omitempty"`
+	Lines            []*OrderLine    `json:"lines,omitempty"`
+	PaymentID        string          `json:"paymentId,omitempty"`
+	OrderID          string          `json:"orderId,omitempty"`
+	CreatedAt        *time.Time      `json:"createdAt,omitempty"`
+	TestMode         bool            `json:"testmode,omitempty"`
+	Links            RefundLinks     `json:"_links,omitempty"`
+	Embedded         *RefundEmbedded `json:"_embedded,omitempty"`
+
+	*rawJSON
 }
 
 // RefundList describes how a list of refunds will be retrieved by Mollie.
@@ -44,8 +51,18 @@ const (
 	Processing RefundStatus = "processing"
 	Refunded   RefundStatus = "refunded"
 	Failed     RefundStatus = "failed"
+
+	// RefundCanceled is prefixed, Canceled is the order status.
+	RefundCanceled RefundStatus = "canceled"
 )
 
+// RefundEmbedded contains the resources embedded in a refund, it is nil
+// unless the refund was retrieved with EmbedPayment. Refunds are sent when
+// creating them, a pointer keeps the field out of the requests.
+type RefundEmbedded struct {
+	Payment *Payment `json:"payment,omitempty"`
+}
+
 // RefundLinks describes all the possible links to be returned with
 // a Refund object.
 type RefundLinks struct {
@@ -56,21 +73,45 @@ type RefundLinks struct {
 	Documentation *URL `json:"documentation,omitempty"`
 }
 
+// RefundEmbeds is a set of resources embedded in refunds, only
+// EmbedPayment is supported.
+type RefundEmbeds []EmbedValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (e RefundEmbeds) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, e, EmbedPayment)
+}
+
+// Has reports whether the set contains the value.
+func (e RefundEmbeds) Has(value EmbedValue) bool {
+	return contains(e, value)
+}
+
+// With returns the set with the value added when missing.
+func (e RefundEmbeds) With(value EmbedValue) RefundEmbeds {
+	return with(e, value)
+}
+
 // RefundOptions describes refund endpoint valid query string parameters.
 //
 // See: https://docs.mollie.com/reference/v2/refunds-api/get-refund.
 type RefundOptions struct {
-	Embed EmbedValue `url:"embed,omitempty"`
+	Embed RefundEmbeds `url:"embed,omitempty"`
 }
 
 // ListRefundOptions describes list refund endpoint valid query string parameters.
 //
+// Mollie has no other filters than the profile ID, Status is not a query
+// parameter and is only used by the refund iterators to filter the
+// results on the client side.
+//
 // See: https://docs.mollie.com/reference/v2/refunds-api/list-refunds.
 type ListRefundOptions struct {
-	From      string     `url:"from,omitempty"`
-	Limit     int        `url:"limit,omitempty"`
-	ProfileID string     `url:"profileId,omitempty"`
-	Embed     EmbedValue `url:"embed,omitempty"`
+	From      string         `url:"from,omitempty"`
+	Limit     int            `url:"limit,omitempty"`
+	ProfileID string         `url:"profileId,omitempty"`
+	Embed     RefundEmbeds   `url:"embed,omitempty"`
+	Status    []RefundStatus `url:"-"`
 }
 
 // RefundsService instance operates over refund resources.
@@ -150,6 +191,104 @@ func (rs *RefundsService) ListRefundPayment(ctx context.Context, paymentID strin
 	return rs.list(ctx, u, opts)
 }
 
+// GetByID retrieves a refund using only its ID.
+//
+// Mollie can only retrieve a refund through its payment, so the refund is
+// first looked up in the top level refunds list using its ID as pagination
+// cursor and then retrieved from its payment, the response returned is the
+// one of the refund. Use an EmbedPayment in the options to include the
+// payment or RefundsService.Payment to follow the payment link.
+//
+// With organization access tokens the refunds list requires a profile ID,
+// use ListRefund with the ProfileID of the options instead.
+//
+// See: https://docs.mollie.com/reference/v2/refunds-api/get-refund
+func (rs *RefundsService) GetByID(ctx context.Context, refundID string, opts *RefundOptions) (res *Response, refund *Refund, err error) {
+	_, rl, err := rs.list(ctx, "v2/refunds", &ListRefundOptions{From: refundID, Limit: 1})
+	if err != nil {
+		return
+	}
+
+	if len(rl.Embedded.Refunds) == 0 || rl.Embedded.Refunds[0].ID != refundID {
+		return nil, nil, fmt.Errorf("refund_error: %s: %w", refundID, errRefundNotFound)
+	}
+
+	return rs.Get(ctx, rl.Embedded.Refunds[0].PaymentID, refundID, opts)
+}
+
+// Payment retrieves the payment a refund belongs to by following the
+// payment link of the refund, the embedded payment is returned without a
+// request when the refund was retrieved with an EmbedPayment.
+func (rs *RefundsService) Payment(ctx context.Context, refund *Refund) (res *Response, p *Payment, err error) {
+	if refund.Embedded != nil && refund.Embedded.Payment != nil {
+		return nil, refund.Embedded.Payment, nil
+	}
+
+	link := refund.Links.Payment
+	if link == nil && refund.PaymentID != "" {
+		link = &URL{Href: fmt.Sprintf("v2/payments/%s", refund.PaymentID)}
+	}
+
+	res, err = rs.client.follow(ctx, link)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &p); err != nil {
+		return
+	}
+
+	return
+}
+
+// Iterate returns an iterator over all the refunds of your account or
+// organization, refunds not matching the statuses of the options are skipped.
+//
+// See https://docs.mollie.com/reference/v2/refunds-api/list-refunds.
+func (rs *RefundsService) Iterate(opts *ListRefundOptions) *Iterator[*Refund] {
+	return rs.iterate("v2/refunds", opts)
+}
+
+// IteratePayment returns an iterator over all the refunds of a payment,
+// refunds not matching the statuses of the options are skipped.
+//
+// See: https://docs.mollie.com/reference/v2/refunds-api/list-refunds
+func (rs *RefundsService) IteratePayment(paymentID string, opts *ListRefundOptions) *Iterator[*Refund] {
+	return rs.iterate(fmt.Sprintf("v2/payments/%s/refunds", paymentID), opts)
+}
+
+func (rs *RefundsService) iterate(uri string, opts *ListRefundOptions) *Iterator[*Refund] {
+	o := ListRefundOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	it := newListIterator[*Refund](rs.client, func(from string) (string, interface{}) {
+		po := o
+		if from != "" {
+			po.From = from
+		}
+
+		return uri, &po
+	})
+
+	it.keep = func(r *Refund) bool {
+		if len(o.Status) == 0 {
+			return true
+		}
+
+		for _, s := range o.Status {
+			if r.Status == s {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return it
+}
+
 func (rs *RefundsService) list(ctx context.Context, uri string, opts interface{}) (res *Response, rl *RefundList, err error) {
 	res, err = rs.client.get(ctx, uri, opts)
 	if err != nil {
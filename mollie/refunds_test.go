@@ -2,6 +2,8 @@ package mollie
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -38,7 +40,7 @@ func (rs *refundsServiceTest) TestRefundsService_Get() {
 				"tr_WDqYK6vllg",
 				"re_4qqhO89gsT",
 				&RefundOptions{
-					Embed: "profile",
+					Embed: RefundEmbeds{EmbedPayment},
 				},
 			},
 			false,
@@ -47,7 +49,11 @@ func (rs *refundsServiceTest) TestRefundsService_Get() {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(rs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
 				testMethod(rs.T(), r, "GET")
-				testQuery(rs.T(), r, "embed=profile&testmode=true")
+				testQuery(rs.T(), r, "embed=payment&testmode=true")
+
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				rs.NotContains(body, "_embedded")
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -144,7 +150,7 @@ func (rs *refundsServiceTest) TestRefundsService_Create() {
 					},
 				},
 				&RefundOptions{
-					Embed: "profile",
+					Embed: RefundEmbeds{EmbedPayment},
 				},
 			},
 			false,
@@ -153,7 +159,7 @@ func (rs *refundsServiceTest) TestRefundsService_Create() {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(rs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
 				testMethod(rs.T(), r, "POST")
-				testQuery(rs.T(), r, "embed=profile&testmode=true")
+				testQuery(rs.T(), r, "embed=payment&testmode=true")
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -543,6 +549,214 @@ func (rs *refundsServiceTest) TestRefundsService_ListPaynents() {
 	}
 }
 
+func (rs *refundsServiceTest) TestRefundsService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/refunds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(rs.T(), r, "GET")
+		testQuery(rs.T(), r, "embed=payment&profileId=pfl_QkEhN94Ba&testmode=true")
+		_, _ = w.Write([]byte(testdata.ListRefundsEmbeddedPaymentResponse))
+	})
+
+	it := tClient.Refunds.Iterate(&ListRefundOptions{
+		ProfileID: "pfl_QkEhN94Ba",
+		Embed:     RefundEmbeds{EmbedPayment},
+		Status:    []RefundStatus{Queued},
+	})
+
+	refunds, err := it.All(context.Background())
+	rs.Nil(err)
+	rs.Len(refunds, 1)
+	rs.Equal("re_4qqhO89gsT", refunds[0].ID)
+	rs.Equal(Queued, refunds[0].Status)
+	rs.Equal("tr_WDqYK6vllg", refunds[0].Embedded.Payment.ID)
+}
+
+func (rs *refundsServiceTest) TestRefundsService_IteratePayment() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg/refunds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(rs.T(), r, "GET")
+		testQuery(rs.T(), r, "testmode=true")
+		_, _ = w.Write([]byte(testdata.ListRefundsEmbeddedPaymentResponse))
+	})
+
+	refunds, err := tClient.Refunds.IteratePayment("tr_WDqYK6vllg", nil).All(context.Background())
+	rs.Nil(err)
+	rs.Len(refunds, 2)
+}
+
+func (rs *refundsServiceTest) TestRefundsService_GetByID() {
+	type args struct {
+		ctx    context.Context
+		refund string
+		opts   *RefundOptions
+	}
+
+	cases := []struct {
+		name          string
+		args          args
+		wantErr       bool
+		err           error
+		pre           func()
+		handler       http.HandlerFunc
+		refundHandler http.HandlerFunc
+	}{
+		{
+			"get refund by id retrieves the refund from its payment.",
+			args{
+				context.Background(),
+				"re_4qqhO89gsT",
+				&RefundOptions{Embed: RefundEmbeds{EmbedPayment}},
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(rs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(rs.T(), r, "GET")
+				testQuery(rs.T(), r, "from=re_4qqhO89gsT&limit=1&testmode=true")
+				_, _ = w.Write([]byte(testdata.GetRefundListResponse))
+			},
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(rs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(rs.T(), r, "GET")
+				testQuery(rs.T(), r, "embed=payment&testmode=true")
+				_, _ = w.Write([]byte(testdata.GetRefundResponse))
+			},
+		},
+		{
+			"get refund by id, the refund does not exist",
+			args{
+				context.Background(),
+				"re_APBiGPH2vV",
+				nil,
+			},
+			true,
+			errRefundNotFound,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testdata.GetRefundListResponse))
+			},
+			func(w http.ResponseWriter, r *http.Request) {
+				rs.Fail("the refund should not be requested")
+			},
+		},
+		{
+			"get refund by id, an error is returned when retrieving the refund",
+			args{
+				context.Background(),
+				"re_4qqhO89gsT",
+				nil,
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testdata.GetRefundListResponse))
+			},
+			errorHandler,
+		},
+		{
+			"get refund by id, an error is returned from the server",
+			args{
+				context.Background(),
+				"re_4qqhO89gsT",
+				nil,
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			errorHandler,
+			errorHandler,
+		},
+		{
+			"get refund by id, an error occurs when parsing json",
+			args{
+				context.Background(),
+				"re_4qqhO89gsT",
+				nil,
+			},
+			true,
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			noPre,
+			encodingHandler,
+			errorHandler,
+		},
+		{
+			"get refund by id, invalid url when building request",
+			args{
+				context.Background(),
+				"re_4qqhO89gsT",
+				nil,
+			},
+			true,
+			errBadBaseURL,
+			crashSrv,
+			errorHandler,
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		rs.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc("/v2/refunds", c.handler)
+			tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg/refunds/re_4qqhO89gsT", c.refundHandler)
+
+			res, refund, err := tClient.Refunds.GetByID(c.args.ctx, c.args.refund, c.args.opts)
+			if c.wantErr {
+				rs.NotNil(err)
+				if errors.Is(c.err, errRefundNotFound) {
+					rs.True(errors.Is(err, errRefundNotFound))
+				} else {
+					rs.EqualError(err, c.err.Error())
+				}
+			} else {
+				rs.Nil(err)
+				rs.Equal(c.args.refund, refund.ID)
+				rs.Equal("/v2/payments/tr_WDqYK6vllg/refunds/re_4qqhO89gsT", res.Request.URL.Path)
+				rs.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func (rs *refundsServiceTest) TestRefundsService_Payment() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(rs.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+		testMethod(rs.T(), r, "GET")
+		_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+	})
+
+	refund := &Refund{
+		PaymentID: "tr_WDqYK6vllg",
+		Links: RefundLinks{
+			Payment: &URL{Href: tServer.URL + "/v2/payments/tr_WDqYK6vllg"},
+		},
+	}
+
+	res, p, err := tClient.Refunds.Payment(context.Background(), refund)
+	rs.Nil(err)
+	rs.Equal("tr_WDqYK6vllg", p.ID)
+	rs.IsType(&http.Response{}, res.Response)
+
+	refund.Embedded = &RefundEmbedded{Payment: p}
+
+	res, embedded, err := tClient.Refunds.Payment(context.Background(), refund)
+	rs.Nil(err)
+	rs.Nil(res)
+	rs.Same(p, embedded)
+}
+
 func TestRefundsService(t *testing.T) {
 	suite.Run(t, new(refundsServiceTest))
 }
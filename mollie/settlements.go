@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -75,13 +76,40 @@ type Settlement struct {
 	Periods   SettlementObject `json:"periods,omitempty"`
 	InvoiceID string           `json:"invoiceId,omitempty"`
 	Links     SettlementLinks  `json:"_links,omitempty"`
+	Embedded  struct {
+		Payments    []*Payment    `json:"payments,omitempty"`
+		Refunds     []*Refund     `json:"refunds,omitempty"`
+		Chargebacks []*Chargeback `json:"chargebacks,omitempty"`
+		Captures    []*Capture    `json:"captures,omitempty"`
+	} `json:"_embedded,omitempty"`
+
+	*rawJSON
+}
+
+// SettlementEmbeds is a set of resources embedded in the payments, refunds,
+// chargebacks and captures listed for a settlement.
+type SettlementEmbeds []EmbedValue
+
+// EncodeValues implements query.Encoder, unsupported values are rejected.
+func (e SettlementEmbeds) EncodeValues(key string, v *url.Values) error {
+	return encodeSet(key, v, e, EmbedPayment, EmbedRefunds, EmbedChargebacks, EmbedCaptures)
+}
+
+// Has reports whether the set contains the value.
+func (e SettlementEmbeds) Has(value EmbedValue) bool {
+	return contains(e, value)
+}
+
+// With returns the set with the value added when missing.
+func (e SettlementEmbeds) With(value EmbedValue) SettlementEmbeds {
+	return with(e, value)
 }
 
 // SettlementsListOptions contains query parameters for settlement lists.
 type SettlementsListOptions struct {
-	From  *ShortDate `url:"from,omitempty"`
-	Limit int        `url:"limit,omitempty"`
-	Embed EmbedValue `url:"embed,omitempty"`
+	From  *ShortDate       `url:"from,omitempty"`
+	Limit int              `url:"limit,omitempty"`
+	Embed SettlementEmbeds `url:"embed,omitempty"`
 }
 
 // SettlementsList describes a list of settlements.
@@ -190,6 +218,68 @@ func (ss *SettlementsService) GetCaptures(ctx context.Context, id string, slo *S
 	return
 }
 
+// IteratePayments returns an iterator over all the payments included
+// in a settlement.
+//
+// See: https://docs.mollie.com/reference/v2/settlements-api/list-settlement-payments
+func (ss *SettlementsService) IteratePayments(id string, slo *SettlementsListOptions) *Iterator[*Payment] {
+	return newListIterator[*Payment](ss.client, ss.pages(id, "payments", slo))
+}
+
+// IterateRefunds returns an iterator over all the refunds included
+// in a settlement.
+//
+// See: https://docs.mollie.com/reference/v2/settlements-api/list-settlement-refunds
+func (ss *SettlementsService) IterateRefunds(id string, slo *SettlementsListOptions) *Iterator[*Refund] {
+	return newListIterator[*Refund](ss.client, ss.pages(id, "refunds", slo))
+}
+
+// IterateChargebacks returns an iterator over all the chargebacks
+// included in a settlement.
+//
+// See: https://docs.mollie.com/reference/v2/settlements-api/list-settlement-chargebacks
+func (ss *SettlementsService) IterateChargebacks(id string, slo *SettlementsListOptions) *Iterator[*Chargeback] {
+	return newListIterator[*Chargeback](ss.client, ss.pages(id, "chargebacks", slo))
+}
+
+// IterateCaptures returns an iterator over all the captures included
+// in a settlement.
+//
+// See: https://docs.mollie.com/reference/v2/settlements-api/list-settlement-captures
+func (ss *SettlementsService) IterateCaptures(id string, slo *SettlementsListOptions) *Iterator[*Capture] {
+	return newListIterator[*Capture](ss.client, ss.pages(id, "captures", slo))
+}
+
+// settlementCursor replaces the date based From of SettlementsListOptions
+// with the resource cursor used to request the following pages.
+type settlementCursor struct {
+	From  string           `url:"from,omitempty"`
+	Limit int              `url:"limit,omitempty"`
+	Embed SettlementEmbeds `url:"embed,omitempty"`
+}
+
+// pages returns the requests of the pages of a settlement list.
+func (ss *SettlementsService) pages(id, category string, slo *SettlementsListOptions) pageRequest {
+	uri := fmt.Sprintf("v2/settlements/%s/%s", id, category)
+
+	return func(from string) (string, interface{}) {
+		if from != "" {
+			c := settlementCursor{From: from}
+			if slo != nil {
+				c.Limit, c.Embed = slo.Limit, slo.Embed
+			}
+
+			return uri, c
+		}
+
+		if slo == nil {
+			return uri, nil
+		}
+
+		return uri, slo
+	}
+}
+
 func (ss *SettlementsService) get(ctx context.Context, element string) (res *Response, s *Settlement, err error) {
 	res, err = ss.client.get(ctx, fmt.Sprintf("v2/settlements/%s", element), nil)
 	if err != nil {
@@ -788,6 +788,58 @@ func (ps *settlementsServiceSuite) TestSettlementsService_GetRefunds() {
 	}
 }
 
+func (ps *settlementsServiceSuite) TestSettlementsService_IteratePayments() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/payments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ps.T(), r, "GET")
+
+		if r.URL.Query().Get("from") == "" {
+			testQuery(ps.T(), r, "limit=4&testmode=true")
+			_, _ = w.Write([]byte(testdata.ListSettlementPaymentsResponse))
+			return
+		}
+
+		testQuery(ps.T(), r, "from=tr_BXjMNJpjEb&limit=4&testmode=true")
+		_, _ = w.Write([]byte(testdata.ListSettlementPaymentsLastPageResponse))
+	})
+
+	payments, err := tClient.Settlements.IteratePayments("stl_jDk30akdN", &SettlementsListOptions{Limit: 4}).All(context.Background())
+
+	ps.Nil(err)
+	ps.Len(payments, 6)
+	ps.Equal("tr_7UhSN1zuXS", payments[0].ID)
+	ps.Equal("tr_CYkNOKqkFc", payments[5].ID)
+}
+
+func (ps *settlementsServiceSuite) TestSettlementsService_IterateTransactions() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/refunds", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.ListSettlementRefundsResponse))
+	})
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/chargebacks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.ListChargebacksResponse))
+	})
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/captures", errorHandler)
+
+	ctx := context.Background()
+
+	refunds, err := tClient.Settlements.IterateRefunds("stl_jDk30akdN", nil).All(ctx)
+	ps.Nil(err)
+	ps.Len(refunds, 2)
+	ps.Equal("-23.20", refunds[1].SettlementAmount.Value)
+
+	chargebacks, err := tClient.Settlements.IterateChargebacks("stl_jDk30akdN", nil).All(ctx)
+	ps.Nil(err)
+	ps.NotEmpty(chargebacks)
+
+	_, err = tClient.Settlements.IterateCaptures("stl_jDk30akdN", nil).All(ctx)
+	ps.EqualError(err, "500 Internal Server Error: An internal server error occurred while processing your request.")
+}
+
 func TestSettlementsService(t *testing.T) {
 	suite.Run(t, new(settlementsServiceSuite))
 }
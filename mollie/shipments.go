@@ -22,6 +22,8 @@ type Shipment struct {
 	Tracking  *ShipmentTracking `json:"tracking,omitempty"`
 	Lines     []*OrderLine      `json:"lines,omitempty"`
 	Links     ShipmentLinks     `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // ShipmentTracking contains shipment tracking details.
@@ -67,6 +69,10 @@ type CreateShipmentRequest struct {
 //
 // See: https://docs.mollie.com/reference/v2/shipments-api/create-shipment
 func (ss *ShipmentsService) Create(ctx context.Context, oID string, cs CreateShipmentRequest) (res *Response, s *Shipment, err error) {
+	if err = ss.client.validate(&cs); err != nil {
+		return
+	}
+
 	uri := fmt.Sprintf("v2/orders/%s/shipments", oID)
 
 	if ss.client.HasAccessToken() && ss.client.config.testing {
@@ -0,0 +1,357 @@
+package mollie
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidInterval          = errors.New("interval must be a positive number followed by days, weeks or months")
+	errIntervalTooLong          = errors.New("interval can't be longer than one year")
+	errIndefiniteSubscription   = errors.New("subscription charges indefinitely")
+	errSubscriptionNotScheduled = errors.New("subscription has no upcoming charge date")
+	errSubscriptionAmount       = errors.New("subscription amount is required")
+	errProrationCurrency        = errors.New("updated amount must use the subscription currency")
+)
+
+// IntervalUnit describes the period unit of a subscription interval.
+type IntervalUnit string
+
+// Supported subscription interval units.
+const (
+	IntervalDays   IntervalUnit = "days"
+	IntervalWeeks  IntervalUnit = "weeks"
+	IntervalMonths IntervalUnit = "months"
+)
+
+// maximum amount of units for each interval unit, Mollie does not
+// accept intervals longer than one year.
+var maxIntervalUnits = map[IntervalUnit]int{
+	IntervalDays:   365,
+	IntervalWeeks:  52,
+	IntervalMonths: 12,
+}
+
+// Interval is the typed representation of the time between two
+// subscription charges, e.g. "1 month", "14 days" or "2 weeks".
+type Interval struct {
+	Count int
+	Unit  IntervalUnit
+}
+
+// ParseInterval parses and validates a subscription interval
+// using the format accepted by Mollie. Both singular and plural
+// units are accepted, so "1 month" and "1 months" are equivalent.
+func ParseInterval(s string) (Interval, error) {
+	i, err := parseInterval(s)
+	if err != nil {
+		return Interval{}, fmt.Errorf("interval_error: %w", err)
+	}
+
+	return i, nil
+}
+
+// parseInterval is ParseInterval without the error prefix, the request
+// validation uses its errors as field details.
+func parseInterval(s string) (Interval, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return Interval{}, fmt.Errorf("%q: %w", s, errInvalidInterval)
+	}
+
+	count, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Interval{}, fmt.Errorf("%q: %w", s, errInvalidInterval)
+	}
+
+	unit := IntervalUnit(strings.ToLower(parts[1]))
+	if !strings.HasSuffix(string(unit), "s") {
+		unit += "s"
+	}
+
+	i := Interval{Count: count, Unit: unit}
+	if err := i.Validate(); err != nil {
+		return Interval{}, fmt.Errorf("%q: %w", s, err)
+	}
+
+	return i, nil
+}
+
+// Validate checks the interval is positive, uses a known unit
+// and does not exceed one year.
+func (i Interval) Validate() error {
+	limit, ok := maxIntervalUnits[i.Unit]
+	if !ok || i.Count <= 0 {
+		return errInvalidInterval
+	}
+
+	if i.Count > limit {
+		return errIntervalTooLong
+	}
+
+	return nil
+}
+
+// String returns the interval in the format expected by Mollie.
+func (i Interval) String() string {
+	unit := string(i.Unit)
+	if i.Count == 1 {
+		unit = strings.TrimSuffix(unit, "s")
+	}
+
+	return fmt.Sprintf("%d %s", i.Count, unit)
+}
+
+// AddTo moves t forward n intervals, a negative n moves it backwards.
+//
+// Monthly intervals keep the day of the month of t, using the last day
+// of the month when the target month is shorter.
+func (i Interval) AddTo(t time.Time, n int) time.Time {
+	switch i.Unit {
+	case IntervalDays:
+		return t.AddDate(0, 0, i.Count*n)
+	case IntervalWeeks:
+		return t.AddDate(0, 0, 7*i.Count*n)
+	case IntervalMonths:
+		y, m, d := t.Date()
+		target := m + time.Month(i.Count*n)
+
+		if last := time.Date(y, target+1, 0, 0, 0, 0, 0, t.Location()).Day(); d > last {
+			d = last
+		}
+
+		hh, mm, ss := t.Clock()
+
+		return time.Date(y, target, d, hh, mm, ss, t.Nanosecond(), t.Location())
+	}
+
+	return t
+}
+
+// SubscriptionProration estimates the effect of changing the amount or
+// interval of a subscription in the middle of a charge period.
+//
+// Credit is the part of the current charge not consumed yet, Charge is
+// what the same days cost with the updated amount and interval and
+// Balance is the difference between both, positive when the customer
+// owes money.
+type SubscriptionProration struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	UnusedDays  int
+	Credit      *Amount
+	Charge      *Amount
+	Balance     *Amount
+}
+
+// ChargeDates projects up to limit upcoming charge dates of the
+// subscription, starting at the next payment date.
+//
+// For subscriptions with a fixed number of charges a limit lower than
+// one returns all the remaining dates, subscriptions charging
+// indefinitely require a positive limit.
+func (s *Subscription) ChargeDates(limit int) ([]time.Time, error) {
+	interval, err := ParseInterval(s.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := s.remainingCharges()
+	if remaining == 0 {
+		return nil, nil
+	}
+
+	if remaining < 0 {
+		if limit <= 0 {
+			return nil, fmt.Errorf("schedule_error: %w", errIndefiniteSubscription)
+		}
+
+		remaining = limit
+	}
+
+	if limit > 0 && limit < remaining {
+		remaining = limit
+	}
+
+	next, err := s.nextChargeDate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates are calculated from the start date to avoid drifting
+	// after a monthly charge fell on a shorter month.
+	anchor := next
+	if s.StartDate != nil && !s.StartDate.After(next) {
+		anchor = s.StartDate.Time
+	}
+
+	dates := make([]time.Time, 0, remaining)
+
+	for k := 0; len(dates) < remaining; k++ {
+		if d := interval.AddTo(anchor, k); !d.Before(next) {
+			dates = append(dates, d)
+		}
+	}
+
+	return dates, nil
+}
+
+// EndDate returns the date of the last charge of a subscription with a
+// fixed number of charges, nil is returned when no charges are left.
+func (s *Subscription) EndDate() (*ShortDate, error) {
+	if s.remainingCharges() < 0 {
+		return nil, fmt.Errorf("schedule_error: %w", errIndefiniteSubscription)
+	}
+
+	dates, err := s.ChargeDates(0)
+	if err != nil || len(dates) == 0 {
+		return nil, err
+	}
+
+	return &ShortDate{dates[len(dates)-1]}, nil
+}
+
+// RemainingValue returns the total amount still to be charged by a
+// subscription with a fixed number of charges.
+func (s *Subscription) RemainingValue() (*Amount, error) {
+	remaining := s.remainingCharges()
+	if remaining < 0 {
+		return nil, fmt.Errorf("schedule_error: %w", errIndefiniteSubscription)
+	}
+
+	if s.Amount == nil {
+		return nil, fmt.Errorf("schedule_error: %w", errSubscriptionAmount)
+	}
+
+	v, err := s.Amount.Rat()
+	if err != nil {
+		return nil, err
+	}
+
+	return AmountFromRat(s.Amount.Currency, v.Mul(v, big.NewRat(int64(remaining), 1))), nil
+}
+
+// EstimateProration estimates the outcome of sending update through
+// SubscriptionsService.Update on the date at. Only the amount and the
+// interval of update are considered, empty fields keep the current
+// subscription values.
+//
+// The estimation is done locally, Mollie does not prorate subscription
+// changes and the new values are used starting on the next charge.
+func (s *Subscription) EstimateProration(update *Subscription, at time.Time) (*SubscriptionProration, error) {
+	current, err := ParseInterval(s.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := current
+	if update != nil && update.Interval != "" {
+		if updated, err = ParseInterval(update.Interval); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.Amount == nil {
+		return nil, fmt.Errorf("proration_error: %w", errSubscriptionAmount)
+	}
+
+	amount := s.Amount
+	if update != nil && update.Amount != nil {
+		amount = update.Amount
+	}
+
+	if amount.Currency != s.Amount.Currency {
+		return nil, fmt.Errorf("proration_error: %w", errProrationCurrency)
+	}
+
+	oldValue, err := s.Amount.Rat()
+	if err != nil {
+		return nil, err
+	}
+
+	newValue, err := amount.Rat()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.NextPaymentDate == nil {
+		return nil, fmt.Errorf("proration_error: %w", errSubscriptionNotScheduled)
+	}
+
+	end := s.NextPaymentDate.Time
+	start := current.AddTo(end, -1)
+
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, end.Location())
+	if day.Before(start) {
+		day = start
+	}
+
+	unused := 0
+	if day.Before(end) {
+		unused = daysBetween(day, end)
+	}
+
+	credit := oldValue.Mul(oldValue, big.NewRat(int64(unused), int64(daysBetween(start, end))))
+	charge := newValue.Mul(newValue, big.NewRat(int64(unused), int64(daysBetween(updated.AddTo(end, -1), end))))
+
+	p := &SubscriptionProration{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		UnusedDays:  unused,
+		Credit:      AmountFromRat(s.Amount.Currency, credit),
+		Charge:      AmountFromRat(s.Amount.Currency, charge),
+		Balance:     AmountFromRat(s.Amount.Currency, new(big.Rat).Sub(charge, credit)),
+	}
+
+	return p, nil
+}
+
+// remainingCharges returns the number of charges left, or -1 when the
+// subscription charges indefinitely.
+func (s *Subscription) remainingCharges() int {
+	switch {
+	case s.Status == SubscriptionStatusCanceled || s.Status == SubscriptionStatusCompleted:
+		return 0
+	case s.Times == 0:
+		return -1
+	case s.TimesRemaining > 0:
+		return s.TimesRemaining
+	case s.Status == "":
+		// Subscriptions not created yet charge the full amount of times.
+		return s.Times
+	}
+
+	return 0
+}
+
+// validateInterval rejects intervals Mollie won't accept before
+// sending a request, empty intervals are left to the API.
+func (s *Subscription) validateInterval() error {
+	if s == nil || s.Interval == "" {
+		return nil
+	}
+
+	_, err := parseInterval(s.Interval)
+
+	return err
+}
+
+func (s *Subscription) nextChargeDate() (time.Time, error) {
+	switch {
+	case s.NextPaymentDate != nil:
+		return s.NextPaymentDate.Time, nil
+	case s.StartDate != nil:
+		return s.StartDate.Time, nil
+	}
+
+	return time.Time{}, fmt.Errorf("schedule_error: %w", errSubscriptionNotScheduled)
+}
+
+func daysBetween(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
@@ -0,0 +1,326 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseInterval(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Interval
+		err  error
+	}{
+		{"1 month", Interval{1, IntervalMonths}, nil},
+		{"3 months", Interval{3, IntervalMonths}, nil},
+		{"14 days", Interval{14, IntervalDays}, nil},
+		{"1 day", Interval{1, IntervalDays}, nil},
+		{"2 weeks", Interval{2, IntervalWeeks}, nil},
+		{"12 Months", Interval{12, IntervalMonths}, nil},
+		{"13 months", Interval{}, errIntervalTooLong},
+		{"53 weeks", Interval{}, errIntervalTooLong},
+		{"366 days", Interval{}, errIntervalTooLong},
+		{"0 days", Interval{}, errInvalidInterval},
+		{"-1 month", Interval{}, errInvalidInterval},
+		{"monthly", Interval{}, errInvalidInterval},
+		{"1 year", Interval{}, errInvalidInterval},
+		{"two weeks", Interval{}, errInvalidInterval},
+		{"", Interval{}, errInvalidInterval},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := ParseInterval(c.in)
+			if c.err != nil {
+				assert.True(t, errors.Is(err, c.err), err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestInterval_String(t *testing.T) {
+	assert.Equal(t, "1 month", Interval{1, IntervalMonths}.String())
+	assert.Equal(t, "14 days", Interval{14, IntervalDays}.String())
+	assert.Equal(t, "1 week", Interval{1, IntervalWeeks}.String())
+}
+
+func TestInterval_AddTo(t *testing.T) {
+	cases := []struct {
+		name     string
+		interval Interval
+		from     time.Time
+		n        int
+		want     time.Time
+	}{
+		{"days", Interval{14, IntervalDays}, date(2023, 1, 25), 1, date(2023, 2, 8)},
+		{"weeks", Interval{2, IntervalWeeks}, date(2023, 1, 1), 3, date(2023, 2, 12)},
+		{"months", Interval{1, IntervalMonths}, date(2023, 1, 15), 1, date(2023, 2, 15)},
+		{"end of month is clamped", Interval{1, IntervalMonths}, date(2023, 1, 31), 1, date(2023, 2, 28)},
+		{"leap years are respected", Interval{1, IntervalMonths}, date(2024, 1, 31), 1, date(2024, 2, 29)},
+		{"months cross years", Interval{3, IntervalMonths}, date(2023, 11, 30), 1, date(2024, 2, 29)},
+		{"backwards", Interval{1, IntervalMonths}, date(2023, 3, 31), -1, date(2023, 2, 28)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, c.interval.AddTo(c.from, c.n))
+		})
+	}
+}
+
+func TestSubscription_ChargeDates(t *testing.T) {
+	cases := []struct {
+		name  string
+		sub   Subscription
+		limit int
+		want  []time.Time
+		err   error
+	}{
+		{
+			"remaining charges of a fixed subscription",
+			Subscription{
+				Status:          SubscriptionStatusActive,
+				Interval:        "1 month",
+				Times:           4,
+				TimesRemaining:  3,
+				StartDate:       &ShortDate{date(2023, 1, 31)},
+				NextPaymentDate: &ShortDate{date(2023, 2, 28)},
+			},
+			0,
+			[]time.Time{date(2023, 2, 28), date(2023, 3, 31), date(2023, 4, 30)},
+			nil,
+		},
+		{
+			"limit caps the amount of dates",
+			Subscription{
+				Status:          SubscriptionStatusActive,
+				Interval:        "2 weeks",
+				Times:           10,
+				TimesRemaining:  10,
+				NextPaymentDate: &ShortDate{date(2023, 1, 2)},
+			},
+			2,
+			[]time.Time{date(2023, 1, 2), date(2023, 1, 16)},
+			nil,
+		},
+		{
+			"indefinite subscriptions use the limit",
+			Subscription{
+				Status:          SubscriptionStatusActive,
+				Interval:        "14 days",
+				NextPaymentDate: &ShortDate{date(2023, 1, 2)},
+			},
+			2,
+			[]time.Time{date(2023, 1, 2), date(2023, 1, 16)},
+			nil,
+		},
+		{
+			"subscriptions not created yet start on the start date",
+			Subscription{
+				Interval:  "1 month",
+				Times:     2,
+				StartDate: &ShortDate{date(2023, 1, 10)},
+			},
+			0,
+			[]time.Time{date(2023, 1, 10), date(2023, 2, 10)},
+			nil,
+		},
+		{
+			"canceled subscriptions have no charges",
+			Subscription{
+				Status:          SubscriptionStatusCanceled,
+				Interval:        "1 month",
+				NextPaymentDate: &ShortDate{date(2023, 1, 2)},
+			},
+			5,
+			nil,
+			nil,
+		},
+		{
+			"indefinite subscriptions require a limit",
+			Subscription{
+				Status:          SubscriptionStatusActive,
+				Interval:        "1 month",
+				NextPaymentDate: &ShortDate{date(2023, 1, 2)},
+			},
+			0,
+			nil,
+			errIndefiniteSubscription,
+		},
+		{
+			"a charge date is required",
+			Subscription{
+				Status:   SubscriptionStatusActive,
+				Interval: "1 month",
+			},
+			1,
+			nil,
+			errSubscriptionNotScheduled,
+		},
+		{
+			"invalid intervals are rejected",
+			Subscription{Interval: "1 year"},
+			1,
+			nil,
+			errInvalidInterval,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.sub.ChargeDates(c.limit)
+			if c.err != nil {
+				assert.True(t, errors.Is(err, c.err), err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestSubscription_EndDateAndRemainingValue(t *testing.T) {
+	s := Subscription{
+		Status:          SubscriptionStatusActive,
+		Amount:          &Amount{Currency: "EUR", Value: "25.00"},
+		Interval:        "1 month",
+		Times:           12,
+		TimesRemaining:  4,
+		StartDate:       &ShortDate{date(2022, 6, 1)},
+		NextPaymentDate: &ShortDate{date(2023, 2, 1)},
+	}
+
+	end, err := s.EndDate()
+	require.Nil(t, err)
+	assert.Equal(t, date(2023, 5, 1), end.Time)
+
+	v, err := s.RemainingValue()
+	require.Nil(t, err)
+	assert.Equal(t, &Amount{Currency: "EUR", Value: "100.00"}, v)
+
+	s.Times, s.TimesRemaining = 0, 0
+
+	_, err = s.EndDate()
+	assert.True(t, errors.Is(err, errIndefiniteSubscription))
+
+	_, err = s.RemainingValue()
+	assert.True(t, errors.Is(err, errIndefiniteSubscription))
+}
+
+func TestSubscription_EstimateProration(t *testing.T) {
+	s := Subscription{
+		Status:          SubscriptionStatusActive,
+		Amount:          &Amount{Currency: "EUR", Value: "30.00"},
+		Interval:        "1 month",
+		NextPaymentDate: &ShortDate{date(2023, 5, 1)},
+	}
+
+	cases := []struct {
+		name   string
+		update *Subscription
+		at     time.Time
+		want   *SubscriptionProration
+		err    error
+	}{
+		{
+			"amount upgrade halfway the period",
+			&Subscription{Amount: &Amount{Currency: "EUR", Value: "60.00"}},
+			date(2023, 4, 16),
+			&SubscriptionProration{
+				PeriodStart: date(2023, 4, 1),
+				PeriodEnd:   date(2023, 5, 1),
+				UnusedDays:  15,
+				Credit:      &Amount{Currency: "EUR", Value: "15.00"},
+				Charge:      &Amount{Currency: "EUR", Value: "30.00"},
+				Balance:     &Amount{Currency: "EUR", Value: "15.00"},
+			},
+			nil,
+		},
+		{
+			"interval change keeping the amount",
+			&Subscription{Interval: "2 months"},
+			date(2023, 4, 16),
+			&SubscriptionProration{
+				PeriodStart: date(2023, 4, 1),
+				PeriodEnd:   date(2023, 5, 1),
+				UnusedDays:  15,
+				Credit:      &Amount{Currency: "EUR", Value: "15.00"},
+				Charge:      &Amount{Currency: "EUR", Value: "7.38"},
+				Balance:     &Amount{Currency: "EUR", Value: "-7.62"},
+			},
+			nil,
+		},
+		{
+			"changes after the period end have nothing to prorate",
+			&Subscription{Amount: &Amount{Currency: "EUR", Value: "60.00"}},
+			date(2023, 5, 1),
+			&SubscriptionProration{
+				PeriodStart: date(2023, 4, 1),
+				PeriodEnd:   date(2023, 5, 1),
+				Credit:      &Amount{Currency: "EUR", Value: "0.00"},
+				Charge:      &Amount{Currency: "EUR", Value: "0.00"},
+				Balance:     &Amount{Currency: "EUR", Value: "0.00"},
+			},
+			nil,
+		},
+		{
+			"currency changes are rejected",
+			&Subscription{Amount: &Amount{Currency: "USD", Value: "60.00"}},
+			date(2023, 4, 16),
+			nil,
+			errProrationCurrency,
+		},
+		{
+			"invalid updated intervals are rejected",
+			&Subscription{Interval: "fortnightly"},
+			date(2023, 4, 16),
+			nil,
+			errInvalidInterval,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := s.EstimateProration(c.update, c.at)
+			if c.err != nil {
+				assert.True(t, errors.Is(err, c.err), err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestSubscriptionsService_CreateInvalidInterval(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	called := false
+	tMux.HandleFunc("/v2/customers/cst_stTC2WHAuS/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	_, _, err := tClient.Subscriptions.Create(context.Background(), "cst_stTC2WHAuS", &Subscription{Interval: "1 year"})
+	assert.True(t, errors.Is(err, errInvalidInterval))
+	assert.False(t, called)
+}
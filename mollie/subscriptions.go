@@ -52,6 +52,8 @@ type Subscription struct {
 	ApplicationFee  *ApplicationFee        `json:"applicationFee,omitempty"`
 	TestMode        bool                   `json:"testmode,omitempty"`
 	Links           SubscriptionLinks      `json:"_links,omitempty"`
+
+	*rawJSON
 }
 
 // SubscriptionList describes the response for subscription list endpoints.
@@ -93,6 +95,10 @@ func (ss *SubscriptionsService) Get(ctx context.Context, cID, sID string) (res *
 func (ss *SubscriptionsService) Create(ctx context.Context, cID string, sc *Subscription) (res *Response, s *Subscription, err error) {
 	uri := fmt.Sprintf("v2/customers/%s/subscriptions", cID)
 
+	if err = ss.client.validate(sc); err != nil {
+		return
+	}
+
 	if ss.client.HasAccessToken() && ss.client.config.testing {
 		sc.TestMode = true
 	}
@@ -114,6 +120,10 @@ func (ss *SubscriptionsService) Create(ctx context.Context, cID string, sc *Subs
 func (ss *SubscriptionsService) Update(ctx context.Context, cID, sID string, sc *Subscription) (res *Response, s *Subscription, err error) {
 	u := fmt.Sprintf("v2/customers/%s/subscriptions/%s", cID, sID)
 
+	if err = ss.client.validate(sc); err != nil {
+		return
+	}
+
 	res, err = ss.client.patch(ctx, u, sc, nil)
 	if err != nil {
 		return
@@ -195,6 +205,24 @@ func (ss *SubscriptionsService) GetPayments(ctx context.Context, cID, sID string
 	return
 }
 
+// Iterate returns an iterator over all the subscriptions of a customer.
+//
+// See: https://docs.mollie.com/reference/v2/subscriptions-api/list-subscriptions
+func (ss *SubscriptionsService) Iterate(cID string, opts *SubscriptionListOptions) *Iterator[*Subscription] {
+	return newListIterator[*Subscription](ss.client, func(from string) (string, interface{}) {
+		o := SubscriptionListOptions{}
+		if opts != nil {
+			o = *opts
+		}
+
+		if from != "" {
+			o.From = from
+		}
+
+		return fmt.Sprintf("v2/customers/%s/subscriptions", cID), &o
+	})
+}
+
 func (ss *SubscriptionsService) list(ctx context.Context, uri string, opts interface{}) (r *Response, err error) {
 	r, err = ss.client.get(ctx, uri, opts)
 	if err != nil {
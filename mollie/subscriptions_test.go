@@ -872,6 +872,25 @@ func (ps *subscriptionsServiceSuite) TestSubscriptionsService_GetPayments() {
 	}
 }
 
+func (ps *subscriptionsServiceSuite) TestSubscriptionsService_Iterate() {
+	setup()
+	defer teardown()
+
+	tMux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(ps.T(), r, "GET")
+		testQuery(ps.T(), r, "profileId=pfl_URR55HPMGx&testmode=true")
+		_, _ = w.Write([]byte(testdata.ListCustomerSubscriptionsResponse))
+	})
+
+	it := tClient.Subscriptions.Iterate("cst_8wmqcHMN4U", &SubscriptionListOptions{ProfileID: "pfl_URR55HPMGx"})
+	subscriptions, err := it.All(context.Background())
+
+	ps.Nil(err)
+	ps.Len(subscriptions, 2)
+	ps.Equal("sub_rVKGtNd6s3", subscriptions[0].ID)
+	ps.Equal("sub_mnfbwhMfvo", subscriptions[1].ID)
+}
+
 func TestSubscriptionService(t *testing.T) {
 	suite.Run(t, new(subscriptionsServiceSuite))
 }
@@ -0,0 +1,215 @@
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLOptions configures a SQLStore.
+type SQLOptions struct {
+	// TablePrefix is prepended to the table names, defaults to "mollie_".
+	TablePrefix string
+	// Placeholder returns the bind parameter of the n-th argument of a
+	// statement, starting at 1. Defaults to a question mark, use Dollar
+	// for PostgreSQL.
+	Placeholder func(n int) string
+}
+
+// Dollar returns PostgreSQL style bind parameters.
+func Dollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// SQLStore is a Store backed by database/sql.
+//
+// Resources are kept in the <prefix>resources table, with their JSON
+// representation in the data column, and checkpoints in the
+// <prefix>checkpoints table. The statements only rely on standard SQL so
+// the store works with the common drivers, it expects to be the only
+// writer of its tables.
+type SQLStore struct {
+	db          *sql.DB
+	resources   string
+	checkpoints string
+	placeholder func(n int) string
+}
+
+// NewSQLStore returns a store using db, a nil options value uses the
+// defaults.
+func NewSQLStore(db *sql.DB, opts *SQLOptions) *SQLStore {
+	o := SQLOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.TablePrefix == "" {
+		o.TablePrefix = "mollie_"
+	}
+
+	if o.Placeholder == nil {
+		o.Placeholder = func(int) string { return "?" }
+	}
+
+	return &SQLStore{
+		db:          db,
+		resources:   o.TablePrefix + "resources",
+		checkpoints: o.TablePrefix + "checkpoints",
+		placeholder: o.Placeholder,
+	}
+}
+
+// CreateTables creates the tables used by the store when they don't exist.
+func (s *SQLStore) CreateTables(ctx context.Context) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS ` + s.resources + ` (
+			kind VARCHAR(16) NOT NULL,
+			id VARCHAR(64) NOT NULL,
+			payment_id VARCHAR(64) NOT NULL,
+			status VARCHAR(32) NOT NULL,
+			final BOOLEAN NOT NULL,
+			created_at TIMESTAMP NULL,
+			synced_at TIMESTAMP NOT NULL,
+			data TEXT NOT NULL,
+			PRIMARY KEY (kind, id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS ` + s.checkpoints + ` (
+			kind VARCHAR(16) NOT NULL PRIMARY KEY,
+			resource_id VARCHAR(64) NOT NULL,
+			created_at TIMESTAMP NULL
+		)`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("sql_store_error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Checkpoint implements Store.
+func (s *SQLStore) Checkpoint(ctx context.Context, kind Kind) (Checkpoint, error) {
+	var (
+		cp      Checkpoint
+		created sql.NullTime
+	)
+
+	q := fmt.Sprintf("SELECT resource_id, created_at FROM %s WHERE kind = %s", s.checkpoints, s.placeholder(1))
+
+	err := s.db.QueryRowContext(ctx, q, string(kind)).Scan(&cp.ID, &created)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return Checkpoint{}, nil
+	}
+
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	cp.CreatedAt = created.Time
+
+	return cp, nil
+}
+
+// SetCheckpoint implements Store.
+func (s *SQLStore) SetCheckpoint(ctx context.Context, kind Kind, cp Checkpoint) error {
+	return s.replace(ctx, s.checkpoints, []string{"kind"},
+		[]string{"kind", "resource_id", "created_at"},
+		string(kind), cp.ID, nullTime(cp.CreatedAt),
+	)
+}
+
+// Save implements Store.
+func (s *SQLStore) Save(ctx context.Context, r *Record) error {
+	data, err := json.Marshal(r.Resource)
+	if err != nil {
+		return fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	return s.replace(ctx, s.resources, []string{"kind", "id"},
+		[]string{"kind", "id", "payment_id", "status", "final", "created_at", "synced_at", "data"},
+		string(r.Kind), r.ID, r.PaymentID, r.Status, r.Final,
+		nullTime(r.CreatedAt), r.SyncedAt, string(data),
+	)
+}
+
+// Pending implements Store.
+func (s *SQLStore) Pending(ctx context.Context, kind Kind) ([]Ref, error) {
+	q := fmt.Sprintf("SELECT id, payment_id FROM %s WHERE kind = %s AND final = %s ORDER BY id",
+		s.resources, s.placeholder(1), s.placeholder(2))
+
+	rows, err := s.db.QueryContext(ctx, q, string(kind), false)
+	if err != nil {
+		return nil, fmt.Errorf("sql_store_error: %w", err)
+	}
+	defer rows.Close()
+
+	var refs []Ref
+
+	for rows.Next() {
+		ref := Ref{Kind: kind}
+		if err := rows.Scan(&ref.ID, &ref.PaymentID); err != nil {
+			return nil, fmt.Errorf("sql_store_error: %w", err)
+		}
+
+		refs = append(refs, ref)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	return refs, nil
+}
+
+// replace deletes the row matching the key columns and inserts the new
+// values in a single transaction, upsert syntax differs between databases.
+func (s *SQLStore) replace(ctx context.Context, table string, keys, columns []string, values ...interface{}) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	where := make([]string, len(keys))
+	for i, k := range keys {
+		where[i] = fmt.Sprintf("%s = %s", k, s.placeholder(i+1))
+	}
+
+	del := fmt.Sprintf("DELETE FROM %s WHERE %s", table, strings.Join(where, " AND "))
+	if _, err = tx.ExecContext(ctx, del, values[:len(keys)]...); err != nil {
+		return fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	params := make([]string, len(columns))
+	for i := range columns {
+		params[i] = s.placeholder(i + 1)
+	}
+
+	ins := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(params, ", "))
+	if _, err = tx.ExecContext(ctx, ins, values...); err != nil {
+		return fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("sql_store_error: %w", err)
+	}
+
+	return nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,251 @@
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tableDriver is a database/sql driver understanding the statements of
+// SQLStore, tables are kept in memory and keyed by their first columns.
+type tableDriver struct {
+	mu     gosync.Mutex
+	tables map[string]map[string][]driver.Value
+	log    []string
+	fail   error
+}
+
+func (d *tableDriver) Open(name string) (driver.Conn, error) { return &tableConn{d}, nil }
+
+type tableConn struct{ d *tableDriver }
+
+func (c *tableConn) Prepare(query string) (driver.Stmt, error) { return &tableStmt{c.d, query}, nil }
+func (c *tableConn) Close() error                              { return nil }
+func (c *tableConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *tableConn) Commit() error                             { return nil }
+func (c *tableConn) Rollback() error                           { return nil }
+
+type tableStmt struct {
+	d     *tableDriver
+	query string
+}
+
+func (s *tableStmt) Close() error  { return nil }
+func (s *tableStmt) NumInput() int { return -1 }
+
+func (s *tableStmt) Exec(args []driver.Value) (driver.Result, error) {
+	d := s.d
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.log = append(d.log, s.query)
+
+	if d.fail != nil {
+		return nil, d.fail
+	}
+
+	f := strings.Fields(s.query)
+
+	switch f[0] {
+	case "CREATE":
+		d.tables[f[5]] = map[string][]driver.Value{}
+	case "DELETE":
+		delete(d.tables[f[2]], rowKey(args))
+	case "INSERT":
+		keys := 1
+		if f[2] == "mollie_resources" {
+			keys = 2
+		}
+
+		d.tables[f[2]][rowKey(args[:keys])] = args
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *tableStmt) Query(args []driver.Value) (driver.Rows, error) {
+	d := s.d
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.fail != nil {
+		return nil, d.fail
+	}
+
+	rows := &tableRows{}
+
+	switch {
+	case strings.Contains(s.query, "FROM mollie_checkpoints"):
+		rows.columns = []string{"resource_id", "created_at"}
+		if row, ok := d.tables["mollie_checkpoints"][rowKey(args)]; ok {
+			rows.values = append(rows.values, []driver.Value{row[1], row[2]})
+		}
+	case strings.Contains(s.query, "FROM mollie_resources"):
+		rows.columns = []string{"id", "payment_id"}
+		for _, row := range d.tables["mollie_resources"] {
+			if row[0] == args[0] && row[4] == args[1] {
+				rows.values = append(rows.values, []driver.Value{row[1], row[2]})
+			}
+		}
+
+		sort.Slice(rows.values, func(i, j int) bool {
+			return rows.values[i][0].(string) < rows.values[j][0].(string)
+		})
+	}
+
+	return rows, nil
+}
+
+func rowKey(values []driver.Value) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+
+	return strings.Join(parts, "/")
+}
+
+type tableRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *tableRows) Columns() []string { return r.columns }
+func (r *tableRows) Close() error      { return nil }
+
+func (r *tableRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+
+	return nil
+}
+
+var drivers gosync.Map
+
+func openTableDB(t *testing.T) (*sql.DB, *tableDriver) {
+	t.Helper()
+
+	d := &tableDriver{tables: map[string]map[string][]driver.Value{}}
+	name := "sync_" + strings.ReplaceAll(t.Name(), "/", "_")
+
+	if _, loaded := drivers.LoadOrStore(name, d); loaded {
+		t.Fatalf("driver %s already registered", name)
+	}
+
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, d
+}
+
+func TestSQLStore(t *testing.T) {
+	db, d := openTableDB(t)
+	ctx := context.Background()
+
+	store := NewSQLStore(db, nil)
+	require.Nil(t, store.CreateTables(ctx))
+	assert.Contains(t, d.log[0], "CREATE TABLE IF NOT EXISTS mollie_resources")
+
+	cp, err := store.Checkpoint(ctx, Payments)
+	require.Nil(t, err)
+	assert.Equal(t, Checkpoint{}, cp)
+
+	created := time.Date(2022, 6, 3, 10, 0, 0, 0, time.UTC)
+	require.Nil(t, store.SetCheckpoint(ctx, Payments, Checkpoint{ID: "tr_3", CreatedAt: created}))
+	require.Nil(t, store.SetCheckpoint(ctx, Payments, Checkpoint{ID: "tr_4", CreatedAt: created.Add(time.Hour)}))
+
+	cp, err = store.Checkpoint(ctx, Payments)
+	require.Nil(t, err)
+	assert.Equal(t, "tr_4", cp.ID)
+	assert.True(t, cp.CreatedAt.Equal(created.Add(time.Hour)))
+
+	records := []*Record{
+		{Ref: Ref{Kind: Payments, ID: "tr_3"}, Status: "open", CreatedAt: created, Resource: &mollie.Payment{ID: "tr_3"}},
+		{Ref: Ref{Kind: Payments, ID: "tr_1"}, Status: "open", Resource: &mollie.Payment{ID: "tr_1"}},
+		{Ref: Ref{Kind: Refunds, ID: "re_1", PaymentID: "tr_3"}, Status: "queued", Resource: &mollie.Refund{ID: "re_1"}},
+		{Ref: Ref{Kind: Payments, ID: "tr_3"}, Status: "paid", Final: true, Resource: &mollie.Payment{ID: "tr_3"}},
+	}
+
+	for _, r := range records {
+		require.Nil(t, store.Save(ctx, r))
+	}
+
+	assert.Len(t, d.tables["mollie_resources"], 3)
+	assert.Equal(t, `{"id":"tr_3","_links":{}}`, d.tables["mollie_resources"]["payments/tr_3"][7])
+	assert.Contains(t, d.log, "INSERT INTO mollie_resources (kind, id, payment_id, status, final, created_at, synced_at, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+
+	pending, err := store.Pending(ctx, Payments)
+	require.Nil(t, err)
+	assert.Equal(t, []Ref{{Kind: Payments, ID: "tr_1"}}, pending)
+
+	pending, err = store.Pending(ctx, Refunds)
+	require.Nil(t, err)
+	assert.Equal(t, []Ref{{Kind: Refunds, ID: "re_1", PaymentID: "tr_3"}}, pending)
+}
+
+func TestSQLStore_Options(t *testing.T) {
+	db, d := openTableDB(t)
+	ctx := context.Background()
+
+	store := NewSQLStore(db, &SQLOptions{TablePrefix: "mollie_", Placeholder: Dollar})
+	require.Nil(t, store.CreateTables(ctx))
+	require.Nil(t, store.SetCheckpoint(ctx, Orders, Checkpoint{ID: "ord_1"}))
+
+	assert.Contains(t, d.log, "DELETE FROM mollie_checkpoints WHERE kind = $1")
+	assert.Contains(t, d.log, "INSERT INTO mollie_checkpoints (kind, resource_id, created_at) VALUES ($1, $2, $3)")
+	assert.Nil(t, d.tables["mollie_checkpoints"]["orders"][2])
+}
+
+func TestSQLStore_DefaultPrefix(t *testing.T) {
+	db, d := openTableDB(t)
+	ctx := context.Background()
+
+	store := NewSQLStore(db, &SQLOptions{Placeholder: Dollar})
+	require.Nil(t, store.CreateTables(ctx))
+	require.Nil(t, store.SetCheckpoint(ctx, Orders, Checkpoint{ID: "ord_1"}))
+
+	assert.Contains(t, d.log, "DELETE FROM mollie_checkpoints WHERE kind = $1")
+	assert.NotNil(t, d.tables["mollie_checkpoints"]["orders"])
+	assert.Nil(t, d.tables["checkpoints"])
+}
+
+func TestSQLStore_Errors(t *testing.T) {
+	db, d := openTableDB(t)
+	ctx := context.Background()
+
+	store := NewSQLStore(db, nil)
+	require.Nil(t, store.CreateTables(ctx))
+
+	d.fail = errors.New("database is locked")
+
+	_, err := store.Checkpoint(ctx, Payments)
+	assert.EqualError(t, err, "sql_store_error: database is locked")
+
+	_, err = store.Pending(ctx, Payments)
+	assert.EqualError(t, err, "sql_store_error: database is locked")
+
+	err = store.Save(ctx, &Record{Ref: Ref{Kind: Payments, ID: "tr_1"}})
+	assert.EqualError(t, err, "sql_store_error: database is locked")
+
+	err = store.Save(ctx, &Record{Ref: Ref{Kind: Payments, ID: "tr_1"}, Resource: make(chan int)})
+	assert.ErrorContains(t, err, "sql_store_error: json: unsupported type")
+}
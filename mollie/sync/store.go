@@ -0,0 +1,118 @@
+package sync
+
+import (
+	"context"
+	"sort"
+	gosync "sync"
+)
+
+// Store persists the synchronized resources and the checkpoints.
+type Store interface {
+	// Checkpoint returns the checkpoint of a kind, the zero value before
+	// the first pull.
+	Checkpoint(ctx context.Context, kind Kind) (Checkpoint, error)
+	// SetCheckpoint replaces the checkpoint of a kind.
+	SetCheckpoint(ctx context.Context, kind Kind, cp Checkpoint) error
+	// Save creates or replaces a resource.
+	Save(ctx context.Context, r *Record) error
+	// Pending returns the resources of a kind that are not final.
+	Pending(ctx context.Context, kind Kind) ([]Ref, error)
+}
+
+// MemoryStore is a Store keeping the records in memory. The checkpoints
+// are lost with the process, the next one pulls every resource again.
+type MemoryStore struct {
+	mu          gosync.Mutex
+	records     map[Ref]Record
+	checkpoints map[Kind]Checkpoint
+}
+
+// NewMemoryStore returns an empty memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records:     map[Ref]Record{},
+		checkpoints: map[Kind]Checkpoint{},
+	}
+}
+
+// Checkpoint implements Store.
+func (m *MemoryStore) Checkpoint(ctx context.Context, kind Kind) (Checkpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.checkpoints[kind], nil
+}
+
+// SetCheckpoint implements Store.
+func (m *MemoryStore) SetCheckpoint(ctx context.Context, kind Kind, cp Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checkpoints[kind] = cp
+
+	return nil
+}
+
+// Save implements Store.
+func (m *MemoryStore) Save(ctx context.Context, r *Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[m.key(r.Ref)] = *r
+
+	return nil
+}
+
+// Pending implements Store.
+func (m *MemoryStore) Pending(ctx context.Context, kind Kind) ([]Ref, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var refs []Ref
+
+	for _, r := range m.records {
+		if r.Kind == kind && !r.Final {
+			refs = append(refs, r.Ref)
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool { return refs[i].ID < refs[j].ID })
+
+	return refs, nil
+}
+
+// Get returns a copy of the record of a resource, or nil when it was
+// never saved.
+func (m *MemoryStore) Get(kind Kind, id string) *Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r, ok := m.records[m.key(Ref{Kind: kind, ID: id})]
+	if !ok {
+		return nil
+	}
+
+	return &r
+}
+
+// Len returns the amount of records of a kind.
+func (m *MemoryStore) Len(kind Kind) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+
+	for k := range m.records {
+		if k.Kind == kind {
+			n++
+		}
+	}
+
+	return n
+}
+
+// key identifies a record by kind and id, resource IDs are unique
+// within a kind.
+func (m *MemoryStore) key(ref Ref) Ref {
+	return Ref{Kind: ref.Kind, ID: ref.ID}
+}
@@ -0,0 +1,379 @@
+// Package sync keeps a local copy of Mollie resources up to date.
+//
+// A Syncer pulls the payments, refunds, chargebacks, orders and customers
+// created since the last run, walking the list endpoints from the newest
+// resource until it reaches the checkpoint of the previous run. Resources
+// that can still change, such as open payments or queued refunds, are
+// fetched again by Refresh until they reach a final status.
+//
+// Every resource is handed to a Store, MemoryStore and SQLStore are
+// provided as reference implementations.
+//
+//	s := sync.New(client, sync.NewSQLStore(db, nil), nil)
+//	err := s.Run(ctx, 15*time.Minute, func(err error) {
+//		log.Println(err)
+//	})
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+)
+
+// DefaultLimit is the page size used to list resources, the maximum
+// allowed by the API.
+const DefaultLimit = 250
+
+// Kind identifies a type of resource.
+type Kind string
+
+// Synchronized resources.
+const (
+	Payments    Kind = "payments"
+	Refunds     Kind = "refunds"
+	Chargebacks Kind = "chargebacks"
+	Orders      Kind = "orders"
+	Customers   Kind = "customers"
+)
+
+// Kinds lists all the synchronized resources.
+var Kinds = []Kind{Payments, Refunds, Chargebacks, Orders, Customers}
+
+// Ref identifies a resource, refunds and chargebacks are only reachable
+// through their payment.
+type Ref struct {
+	Kind      Kind
+	ID        string
+	PaymentID string
+}
+
+// Record is the copy of a resource handed to the store.
+type Record struct {
+	Ref
+	Status string
+	// Final resources are not fetched again by Refresh.
+	Final     bool
+	CreatedAt time.Time
+	SyncedAt  time.Time
+	// Resource is the *mollie.Payment, *mollie.Refund, *mollie.Chargeback,
+	// *mollie.Order or *mollie.Customer as returned by the API.
+	Resource interface{}
+}
+
+// Checkpoint is the newest resource of a kind seen by a previous run.
+type Checkpoint struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+// Stats counts the resources saved per kind.
+type Stats map[Kind]int
+
+// Options configures a syncer.
+type Options struct {
+	// Kinds limits the synchronized resources, all of them by default.
+	Kinds []Kind
+	// Limit is the page size, defaults to DefaultLimit.
+	Limit int
+	// ProfileID is required by the list endpoints when using
+	// organization access tokens.
+	ProfileID string
+}
+
+// Syncer copies resources from the API to a store.
+type Syncer struct {
+	client *mollie.Client
+	store  Store
+	opts   Options
+	now    func() time.Time
+}
+
+// New creates a syncer, a nil options value uses the defaults.
+func New(client *mollie.Client, store Store, opts *Options) *Syncer {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if len(o.Kinds) == 0 {
+		o.Kinds = Kinds
+	}
+
+	if o.Limit <= 0 {
+		o.Limit = DefaultLimit
+	}
+
+	return &Syncer{client: client, store: store, opts: o, now: time.Now}
+}
+
+// Pull saves the resources created since the checkpoint of every kind,
+// all of them on the first run. The checkpoint only moves once all the
+// new resources of a kind have been saved, an interrupted pull starts
+// over on the next run.
+func (s *Syncer) Pull(ctx context.Context) (Stats, error) {
+	stats := Stats{}
+
+	for _, kind := range s.opts.Kinds {
+		var (
+			n   int
+			err error
+		)
+
+		switch kind {
+		case Payments:
+			n, err = pull(ctx, s, kind, s.client.Payments.Iterate(&mollie.ListPaymentOptions{
+				Limit: s.opts.Limit, ProfileID: s.opts.ProfileID,
+			}), paymentRecord)
+		case Refunds:
+			n, err = pull(ctx, s, kind, s.client.Refunds.Iterate(&mollie.ListRefundOptions{
+				Limit: s.opts.Limit, ProfileID: s.opts.ProfileID,
+			}), refundRecord)
+		case Chargebacks:
+			n, err = pull(ctx, s, kind, s.client.Chargebacks.Iterate(&mollie.ChargebacksListOptions{
+				Limit: s.opts.Limit, ProfileID: s.opts.ProfileID,
+			}), chargebackRecord)
+		case Orders:
+			n, err = pull(ctx, s, kind, s.client.Orders.Iterate(&mollie.OrderListOptions{
+				Limit: s.opts.Limit, ProfileID: s.opts.ProfileID,
+			}), orderRecord)
+		case Customers:
+			n, err = pull(ctx, s, kind, s.client.Customers.Iterate(&mollie.CustomersListOptions{
+				Limit: s.opts.Limit,
+			}), customerRecord)
+		default:
+			err = fmt.Errorf("sync_error: unknown kind %q", kind)
+		}
+
+		stats[kind] = n
+
+		if err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+func pull[T any](ctx context.Context, s *Syncer, kind Kind, it *mollie.Iterator[T], record func(T) *Record) (int, error) {
+	defer it.Close()
+
+	cp, err := s.store.Checkpoint(ctx, kind)
+	if err != nil {
+		return 0, fmt.Errorf("sync_error: %s checkpoint: %w", kind, err)
+	}
+
+	var newest *Record
+
+	n := 0
+
+	for it.Next(ctx) {
+		r := record(it.Value())
+
+		// lists start with the newest resources, everything past the
+		// checkpoint was saved by a previous run.
+		if r.ID == cp.ID || (!cp.CreatedAt.IsZero() && r.CreatedAt.Before(cp.CreatedAt)) {
+			break
+		}
+
+		r.SyncedAt = s.now()
+
+		if err := s.store.Save(ctx, r); err != nil {
+			return n, fmt.Errorf("sync_error: save %s %s: %w", kind, r.ID, err)
+		}
+
+		if newest == nil {
+			newest = r
+		}
+
+		n++
+	}
+
+	if err := it.Err(); err != nil {
+		return n, fmt.Errorf("sync_error: list %s: %w", kind, err)
+	}
+
+	if newest != nil {
+		if err := s.store.SetCheckpoint(ctx, kind, Checkpoint{ID: newest.ID, CreatedAt: newest.CreatedAt}); err != nil {
+			return n, fmt.Errorf("sync_error: %s checkpoint: %w", kind, err)
+		}
+	}
+
+	return n, nil
+}
+
+// Refresh fetches the resources that are not final again and saves
+// their current state.
+func (s *Syncer) Refresh(ctx context.Context) (Stats, error) {
+	stats := Stats{}
+
+	for _, kind := range s.opts.Kinds {
+		refs, err := s.store.Pending(ctx, kind)
+		if err != nil {
+			return stats, fmt.Errorf("sync_error: pending %s: %w", kind, err)
+		}
+
+		for _, ref := range refs {
+			r, err := s.fetch(ctx, ref)
+			if err != nil {
+				return stats, fmt.Errorf("sync_error: refresh %s %s: %w", kind, ref.ID, err)
+			}
+
+			r.SyncedAt = s.now()
+
+			if err := s.store.Save(ctx, r); err != nil {
+				return stats, fmt.Errorf("sync_error: save %s %s: %w", kind, ref.ID, err)
+			}
+
+			stats[kind]++
+		}
+	}
+
+	return stats, nil
+}
+
+// Run pulls and refreshes the resources every interval until ctx is done.
+// The errors of a round are passed to onError, when not nil, and the next
+// round runs as scheduled.
+func (s *Syncer) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Pull(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		if _, err := s.Refresh(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Syncer) fetch(ctx context.Context, ref Ref) (*Record, error) {
+	switch ref.Kind {
+	case Payments:
+		_, p, err := s.client.Payments.Get(ctx, ref.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return paymentRecord(p), nil
+	case Refunds:
+		_, r, err := s.client.Refunds.Get(ctx, ref.PaymentID, ref.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return refundRecord(r), nil
+	case Chargebacks:
+		_, c, err := s.client.Chargebacks.Get(ctx, ref.PaymentID, ref.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return chargebackRecord(c), nil
+	case Orders:
+		_, o, err := s.client.Orders.Get(ctx, ref.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return orderRecord(o), nil
+	case Customers:
+		_, c, err := s.client.Customers.Get(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return customerRecord(c), nil
+	}
+
+	return nil, fmt.Errorf("unknown kind %q", ref.Kind)
+}
+
+// Final statuses, resources in any other status are refreshed.
+var (
+	finalPayments = map[mollie.PaymentStatus]bool{
+		mollie.PaymentPaid:     true,
+		mollie.PaymentCanceled: true,
+		mollie.PaymentExpired:  true,
+		mollie.PaymentFailed:   true,
+	}
+	finalRefunds = map[mollie.RefundStatus]bool{mollie.Refunded: true, mollie.Failed: true, mollie.RefundCanceled: true}
+	finalOrders  = map[mollie.OrderStatus]bool{mollie.Completed: true, mollie.Canceled: true, mollie.Expired: true}
+)
+
+func created(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
+func paymentRecord(p *mollie.Payment) *Record {
+	return &Record{
+		Ref:       Ref{Kind: Payments, ID: p.ID},
+		Status:    string(p.Status),
+		Final:     finalPayments[p.Status],
+		CreatedAt: created(p.CreatedAt),
+		Resource:  p,
+	}
+}
+
+func refundRecord(r *mollie.Refund) *Record {
+	return &Record{
+		Ref:       Ref{Kind: Refunds, ID: r.ID, PaymentID: r.PaymentID},
+		Status:    string(r.Status),
+		Final:     finalRefunds[r.Status],
+		CreatedAt: created(r.CreatedAt),
+		Resource:  r,
+	}
+}
+
+// chargebackRecord treats chargebacks as final, a reversal is only seen
+// when the chargeback is listed again.
+func chargebackRecord(c *mollie.Chargeback) *Record {
+	status := "charged_back"
+	if c.ReversedAt != nil {
+		status = "reversed"
+	}
+
+	return &Record{
+		Ref:       Ref{Kind: Chargebacks, ID: c.ID, PaymentID: c.PaymentID},
+		Status:    status,
+		Final:     true,
+		CreatedAt: created(c.CreatedAt),
+		Resource:  c,
+	}
+}
+
+func orderRecord(o *mollie.Order) *Record {
+	return &Record{
+		Ref:       Ref{Kind: Orders, ID: o.ID},
+		Status:    string(o.Status),
+		Final:     finalOrders[o.Status],
+		CreatedAt: created(o.CreatedAt),
+		Resource:  o,
+	}
+}
+
+// customerRecord treats customers as final, they have no status and are
+// only pulled when created.
+func customerRecord(c *mollie.Customer) *Record {
+	return &Record{
+		Ref:       Ref{Kind: Customers, ID: c.ID},
+		Final:     true,
+		CreatedAt: created(c.CreatedAt),
+		Resource:  c,
+	}
+}
@@ -0,0 +1,185 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v3/mollie/internal/mollietest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paymentsPage = `{
+    "count": 2,
+    "_embedded": {"payments": [
+        %s
+        {"resource": "payment", "id": "tr_3", "status": "open", "createdAt": "2022-06-03T10:00:00+00:00"},
+        {"resource": "payment", "id": "tr_2", "status": "paid", "createdAt": "2022-06-02T10:00:00+00:00"}
+    ]},
+    "_links": {"next": {"href": "https://api.mollie.com/v2/payments?from=tr_1&limit=250"}}
+}`
+
+const paymentsLastPage = `{
+    "count": 1,
+    "_embedded": {"payments": [
+        {"resource": "payment", "id": "tr_1", "status": "open", "createdAt": "2022-06-01T10:00:00+00:00"}
+    ]},
+    "_links": {"next": null}
+}`
+
+var responses = map[string]string{
+	"/v2/refunds": `{"count": 1, "_embedded": {"refunds": [
+        {"resource": "refund", "id": "re_1", "paymentId": "tr_2", "status": "queued", "createdAt": "2022-06-02T12:00:00+00:00"}
+    ]}, "_links": {}}`,
+	"/v2/chargebacks": `{"count": 1, "_embedded": {"chargebacks": [
+        {"resource": "chargeback", "id": "chb_1", "paymentId": "tr_2", "createdAt": "2022-06-02T13:00:00+00:00"}
+    ]}, "_links": {}}`,
+	"/v2/orders": `{"count": 1, "_embedded": {"orders": [
+        {"resource": "order", "id": "ord_1", "status": "created", "createdAt": "2022-06-02T14:00:00+00:00"}
+    ]}, "_links": {}}`,
+	"/v2/customers": `{"count": 1, "_embedded": {"customers": [
+        {"resource": "customer", "id": "cst_1", "createdAt": "2022-06-01T09:00:00+00:00"}
+    ]}, "_links": {}}`,
+	"/v2/payments/tr_1":              `{"resource": "payment", "id": "tr_1", "status": "open", "createdAt": "2022-06-01T10:00:00+00:00"}`,
+	"/v2/payments/tr_3":              `{"resource": "payment", "id": "tr_3", "status": "paid", "createdAt": "2022-06-03T10:00:00+00:00"}`,
+	"/v2/payments/tr_4":              `{"resource": "payment", "id": "tr_4", "status": "expired", "createdAt": "2022-06-04T10:00:00+00:00"}`,
+	"/v2/payments/tr_2/refunds/re_1": `{"resource": "refund", "id": "re_1", "paymentId": "tr_2", "status": "refunded"}`,
+	"/v2/orders/ord_1":               `{"resource": "order", "id": "ord_1", "status": "completed"}`,
+}
+
+type server struct {
+	newPayment bool
+	lastPages  int
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v2/payments" {
+		if r.URL.Query().Get("from") == "tr_1" {
+			s.lastPages++
+			_, _ = w.Write([]byte(paymentsLastPage))
+
+			return
+		}
+
+		newest := ""
+		if s.newPayment {
+			newest = `{"resource": "payment", "id": "tr_4", "status": "open", "createdAt": "2022-06-04T10:00:00+00:00"},`
+		}
+
+		_, _ = fmt.Fprintf(w, paymentsPage, newest)
+
+		return
+	}
+
+	body, ok := responses[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status": 404, "title": "Not Found", "detail": "No resource exists with token"}`))
+
+		return
+	}
+
+	_, _ = w.Write([]byte(body))
+}
+
+func TestSyncer(t *testing.T) {
+	srv := &server{}
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	s := New(mollietest.NewClient(t, srv), store, nil)
+	s.now = func() time.Time { return time.Date(2022, 6, 5, 0, 0, 0, 0, time.UTC) }
+
+	stats, err := s.Pull(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, Stats{Payments: 3, Refunds: 1, Chargebacks: 1, Orders: 1, Customers: 1}, stats)
+
+	cp, _ := store.Checkpoint(ctx, Payments)
+	assert.Equal(t, "tr_3", cp.ID)
+
+	p := store.Get(Payments, "tr_2")
+	require.NotNil(t, p)
+	assert.True(t, p.Final)
+	assert.Equal(t, "paid", p.Status)
+	assert.Equal(t, s.now(), p.SyncedAt)
+	assert.IsType(t, &mollie.Payment{}, p.Resource)
+
+	r := store.Get(Refunds, "re_1")
+	require.NotNil(t, r)
+	assert.False(t, r.Final)
+	assert.Equal(t, "tr_2", r.PaymentID)
+
+	t.Run("only new resources are pulled", func(t *testing.T) {
+		srv.newPayment = true
+
+		stats, err := s.Pull(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, 1, stats[Payments])
+		assert.Equal(t, 0, stats[Refunds])
+		assert.Equal(t, 1, srv.lastPages)
+		assert.Equal(t, 4, store.Len(Payments))
+
+		cp, _ := store.Checkpoint(ctx, Payments)
+		assert.Equal(t, "tr_4", cp.ID)
+	})
+
+	t.Run("pending resources are refreshed", func(t *testing.T) {
+		stats, err := s.Refresh(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, Stats{Payments: 3, Refunds: 1, Orders: 1}, stats)
+
+		assert.True(t, store.Get(Payments, "tr_3").Final)
+		assert.True(t, store.Get(Refunds, "re_1").Final)
+		assert.Equal(t, "completed", store.Get(Orders, "ord_1").Status)
+
+		pending, _ := store.Pending(ctx, Payments)
+		assert.Equal(t, []Ref{{Kind: Payments, ID: "tr_1"}}, pending)
+	})
+}
+
+func TestSyncer_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("list errors stop the pull", func(t *testing.T) {
+		s := New(mollietest.NewClient(t, http.NotFoundHandler()), NewMemoryStore(), &Options{Kinds: []Kind{Customers}})
+
+		_, err := s.Pull(ctx)
+		assert.ErrorContains(t, err, "sync_error: list customers")
+	})
+
+	t.Run("unknown kinds", func(t *testing.T) {
+		s := New(mollietest.NewClient(t, http.NotFoundHandler()), NewMemoryStore(), &Options{Kinds: []Kind{"invoices"}})
+
+		_, err := s.Pull(ctx)
+		assert.EqualError(t, err, `sync_error: unknown kind "invoices"`)
+	})
+
+	t.Run("refresh errors", func(t *testing.T) {
+		store := NewMemoryStore()
+		_ = store.Save(ctx, &Record{Ref: Ref{Kind: Payments, ID: "tr_gone"}})
+
+		s := New(mollietest.NewClient(t, &server{}), store, nil)
+
+		_, err := s.Refresh(ctx)
+		assert.EqualError(t, err, "sync_error: refresh payments tr_gone: 404 Not Found: No resource exists with token")
+	})
+
+	t.Run("run reports errors until the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		s := New(mollietest.NewClient(t, http.NotFoundHandler()), NewMemoryStore(), &Options{Kinds: []Kind{Orders}})
+
+		var errs []error
+
+		err := s.Run(ctx, time.Millisecond, func(err error) {
+			errs = append(errs, err)
+			cancel()
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, errs, 1)
+	})
+}
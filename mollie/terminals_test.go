@@ -0,0 +1,229 @@
+package mollie
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v3/testdata"
+	"github.com/stretchr/testify/suite"
+)
+
+type terminalsServiceSuite struct{ suite.Suite }
+
+func (ts *terminalsServiceSuite) SetupSuite() { setEnv() }
+
+func (ts *terminalsServiceSuite) TearDownSuite() { unsetEnv() }
+
+func (ts *terminalsServiceSuite) TestTerminalsService_Get() {
+	type args struct {
+		ctx      context.Context
+		terminal string
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"get terminal works as expected.",
+			args{
+				context.Background(),
+				"term_7MgL4wea46qkRcoTZjWEH",
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(ts.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(ts.T(), r, "GET")
+				testQuery(ts.T(), r, "testmode=true")
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				_, _ = w.Write([]byte(testdata.GetTerminalResponse))
+			},
+		},
+		{
+			"get terminal, an error is returned from the server",
+			args{
+				context.Background(),
+				"term_7MgL4wea46qkRcoTZjWEH",
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			errorHandler,
+		},
+		{
+			"get terminal, an error occurs when parsing json",
+			args{
+				context.Background(),
+				"term_7MgL4wea46qkRcoTZjWEH",
+			},
+			true,
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			noPre,
+			encodingHandler,
+		},
+		{
+			"get terminal, invalid url when building request",
+			args{
+				context.Background(),
+				"term_7MgL4wea46qkRcoTZjWEH",
+			},
+			true,
+			errBadBaseURL,
+			crashSrv,
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		ts.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc(fmt.Sprintf("/v2/terminals/%s", c.args.terminal), c.handler)
+
+			res, term, err := tClient.Terminals.Get(c.args.ctx, c.args.terminal)
+			if c.wantErr {
+				ts.NotNil(err)
+				ts.EqualError(err, c.err.Error())
+			} else {
+				ts.Nil(err)
+				ts.IsType(&Terminal{}, term)
+				ts.Equal(TerminalActive, term.Status)
+				ts.Equal("PAX", term.Brand)
+				ts.Equal("A920", term.Model)
+				ts.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func (ts *terminalsServiceSuite) TestTerminalsService_List() {
+	type args struct {
+		ctx     context.Context
+		options *TerminalListOptions
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+		pre     func()
+		handler http.HandlerFunc
+	}{
+		{
+			"list terminals works as expected.",
+			args{
+				context.Background(),
+				nil,
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(ts.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(ts.T(), r, "GET")
+				testQuery(ts.T(), r, "testmode=true")
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				_, _ = w.Write([]byte(testdata.ListTerminalsResponse))
+			},
+		},
+		{
+			"list terminals with options works as expected.",
+			args{
+				context.Background(),
+				&TerminalListOptions{
+					ProfileID: "pfl_QkEhN94Ba",
+					Limit:     5,
+				},
+			},
+			false,
+			nil,
+			noPre,
+			func(w http.ResponseWriter, r *http.Request) {
+				testHeader(ts.T(), r, AuthHeader, "Bearer token_X12b31ggg23")
+				testMethod(ts.T(), r, "GET")
+				testQuery(ts.T(), r, "limit=5&profileId=pfl_QkEhN94Ba&testmode=true")
+
+				if _, ok := r.Header[AuthHeader]; !ok {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				_, _ = w.Write([]byte(testdata.ListTerminalsResponse))
+			},
+		},
+		{
+			"list terminals, an error is returned from the server",
+			args{
+				context.Background(),
+				nil,
+			},
+			true,
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request."),
+			noPre,
+			errorHandler,
+		},
+		{
+			"list terminals, an error occurs when parsing json",
+			args{
+				context.Background(),
+				nil,
+			},
+			true,
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			noPre,
+			encodingHandler,
+		},
+		{
+			"list terminals, invalid url when building request",
+			args{
+				context.Background(),
+				nil,
+			},
+			true,
+			errBadBaseURL,
+			crashSrv,
+			errorHandler,
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		ts.T().Run(c.name, func(t *testing.T) {
+			c.pre()
+			tMux.HandleFunc("/v2/terminals", c.handler)
+
+			res, tl, err := tClient.Terminals.List(c.args.ctx, c.args.options)
+			if c.wantErr {
+				ts.NotNil(err)
+				ts.EqualError(err, c.err.Error())
+			} else {
+				ts.Nil(err)
+				ts.IsType(&TerminalList{}, tl)
+				ts.Len(tl.Embedded.Terminals, 2)
+				ts.Equal(TerminalPending, tl.Embedded.Terminals[1].Status)
+				ts.IsType(&http.Response{}, res.Response)
+			}
+		})
+	}
+}
+
+func TestTerminalsService(t *testing.T) {
+	suite.Run(t, new(terminalsServiceSuite))
+}
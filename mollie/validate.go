@@ -0,0 +1,423 @@
+package mollie
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// validationTitle is the title of the field errors found locally, the
+// same the API uses for invalid requests.
+var validationTitle = http.StatusText(http.StatusUnprocessableEntity)
+
+var (
+	currencyFormat = regexp.MustCompile(`^[A-Z]{3}$`)
+	countryFormat  = regexp.MustCompile(`^[A-Z]{2}$`)
+	phoneFormat    = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+)
+
+// locales accepted by Mollie.
+var locales = map[Locale]bool{
+	English: true, EnglishGB: true, Dutch: true, DutchBelgium: true,
+	French: true, FrenchBelgium: true, German: true, GermanAustria: true,
+	GermanSwiss: true, Spanish: true, Catalan: true, Portuguese: true,
+	Italian: true, Norwegian: true, Swedish: true, Finish: true,
+	Danish: true, Icelandic: true, Hungarian: true, Polish: true,
+	Latvian: true, Lithuanian: true,
+}
+
+// ValidationError lists the invalid fields of a request found before
+// sending it. Every field error is a BaseError with the 422 status the
+// API would have responded with, errors.As finds the first one.
+//
+// errors.Is also matches the errors of the local checks, like the ones
+// returned by Payment.ValidateRouting or Order.ValidateAmounts.
+type ValidationError struct {
+	Fields []*BaseError
+
+	causes []error
+}
+
+// Error interface compliance.
+func (ve *ValidationError) Error() string {
+	msgs := make([]string, len(ve.Fields))
+	for i, f := range ve.Fields {
+		msgs[i] = f.Error()
+	}
+
+	return "validation_error: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the first field error.
+func (ve *ValidationError) Unwrap() error {
+	if len(ve.Fields) == 0 {
+		return nil
+	}
+
+	return ve.Fields[0]
+}
+
+// Is reports whether one of the local checks failed with target.
+func (ve *ValidationError) Is(target error) bool {
+	for _, err := range ve.causes {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validator collects field errors, nested fields are joined with dots
+// like the API does.
+type validator struct {
+	fields []*BaseError
+	causes []error
+}
+
+func (v *validator) add(field, detail string) {
+	v.fields = append(v.fields, &BaseError{
+		Status: http.StatusUnprocessableEntity,
+		Title:  validationTitle,
+		Detail: detail,
+		Field:  field,
+	})
+}
+
+// addErr adds the error of a local check, its message is the detail.
+func (v *validator) addErr(field string, err error) {
+	v.add(field, err.Error())
+	v.causes = append(v.causes, err)
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: v.fields, causes: v.causes}
+}
+
+func (v *validator) amount(field string, a *Amount) {
+	if a == nil {
+		return
+	}
+
+	if !currencyFormat.MatchString(a.Currency) {
+		v.add(field+".currency", "The currency must be an ISO 4217 code.")
+
+		return
+	}
+
+	r, ok := new(big.Rat).SetString(a.Value)
+	if !ok || a.Value != r.FloatString(currencyDecimals(a.Currency)) {
+		v.add(field+".value", fmt.Sprintf("The amount must be a decimal number with %d decimals for %s.", currencyDecimals(a.Currency), a.Currency))
+	}
+}
+
+func (v *validator) metadata(field string, md interface{}) {
+	if emptyMetadata(md) {
+		return
+	}
+
+	b, err := metadataJSON(md)
+	if err == nil {
+		_, err = EncodeMetadata(b)
+	}
+
+	if err != nil {
+		v.add(field, "The metadata must be valid JSON of at most 1024 bytes.")
+	}
+}
+
+func (v *validator) locale(field string, l Locale) {
+	if l != "" && !locales[l] {
+		v.add(field, fmt.Sprintf("The locale %q is not supported.", l))
+	}
+}
+
+func (v *validator) phone(field string, p string) {
+	if p != "" && !phoneFormat.MatchString(p) {
+		v.add(field, "The phone number must be in the E.164 format, like +31208202070.")
+	}
+}
+
+func (v *validator) email(field, e string) {
+	if e == "" {
+		return
+	}
+
+	if a, err := mail.ParseAddress(e); err != nil || a.Address != e {
+		v.add(field, "The email address is not valid.")
+	}
+}
+
+func (v *validator) url(field, raw string) {
+	if raw == "" {
+		return
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(field, "The URL must be an absolute http or https URL.")
+	}
+}
+
+func (v *validator) country(field, c string) {
+	if c != "" && !countryFormat.MatchString(c) {
+		v.add(field, "The country must be an ISO 3166-1 alpha-2 code.")
+	}
+}
+
+// address checks the all or nothing rule of addresses: when any field is
+// given all are required except for the street additional and region.
+func (v *validator) address(field string, a Address) {
+	if a == (Address{}) {
+		return
+	}
+
+	required := []struct{ name, value string }{
+		{"streetAndNumber", a.StreetAndNumber},
+		{"postalCode", a.PostalCode},
+		{"city", a.City},
+		{"country", a.Country},
+	}
+
+	for _, r := range required {
+		if r.value == "" {
+			v.add(field+"."+r.name, "The field is required when an address is provided.")
+		}
+	}
+
+	v.country(field+".country", a.Country)
+}
+
+func (v *validator) orderAddress(field string, a *OrderAddress) {
+	if a == nil {
+		return
+	}
+
+	v.address(field, Address{
+		StreetAndNumber:  a.StreetAndNumber,
+		StreetAdditional: a.StreetAdditional,
+		PostalCode:       a.PostalCode,
+		City:             a.City,
+		Region:           a.Region,
+		Country:          a.Country,
+	})
+	v.email(field+".email", a.Email)
+	v.phone(field+".phone", string(a.Phone))
+}
+
+// validate is called by the services before sending a request, unless
+// the validation is disabled in the config.
+func (c *Client) validate(r interface{ Validate() error }) error {
+	if c.config != nil && !c.config.validation {
+		return nil
+	}
+
+	return r.Validate()
+}
+
+// Validate checks the fields of a payment before it is created or
+// updated.
+func (p *Payment) Validate() error {
+	v := &validator{}
+
+	v.amount("amount", p.Amount)
+	v.locale("locale", p.Locale)
+	v.email("billingEmail", p.BillingEmail)
+	v.url("redirectUrl", p.RedirectURL)
+	v.url("webhookUrl", p.WebhookURL)
+	v.metadata("metadata", p.Metadata)
+
+	if p.BillingAddress != nil {
+		v.address("billingAddress", *p.BillingAddress)
+	}
+
+	if a := p.ShippingAddress; a != nil {
+		v.orderAddress("shippingAddress", &OrderAddress{
+			OrganizationName: a.OrganizationName,
+			Email:            a.Email,
+			Phone:            a.Phone,
+			StreetAndNumber:  a.StreetAndNumber,
+			StreetAdditional: a.StreetAdditional,
+			PostalCode:       a.PostalCode,
+			City:             a.City,
+			Region:           a.Region,
+			Country:          a.Country,
+		})
+	}
+
+	if err := p.checkRouting(); err != nil {
+		v.addErr("routing", err)
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields of an order before it is created or
+// updated, the amounts are checked with ValidateAmounts when the order
+// has lines.
+func (o *Order) Validate() error {
+	v := &validator{}
+
+	v.amount("amount", o.Amount)
+	v.locale("locale", o.Locale)
+	v.url("redirectUrl", o.RedirectURL)
+	v.url("webhookUrl", o.WebhookURL)
+	v.metadata("metadata", o.Metadata)
+	v.orderAddress("billingAddress", o.BillingAddress)
+	v.orderAddress("shippingAddress", &o.ShippingAddress)
+
+	for i, l := range o.Lines {
+		field := fmt.Sprintf("lines.%d", i)
+
+		if l.Name == "" {
+			v.add(field+".name", "The name of the line is required.")
+		}
+
+		v.amount(field+".unitPrice", l.UnitPrice)
+		v.amount(field+".discountAmount", l.DiscountAmount)
+		v.amount(field+".totalAmount", l.TotalAmount)
+		v.amount(field+".vatAmount", l.VatAmount)
+		v.url(field+".imageUrl", l.ImageURL)
+		v.url(field+".productUrl", l.ProductURL)
+		v.metadata(field+".metadata", l.Metadata)
+	}
+
+	if len(o.Lines) > 0 && len(v.fields) == 0 {
+		if err := o.checkAmounts(); err != nil {
+			field := "lines"
+			if errors.Is(err, errOrderAmount) {
+				field = "amount"
+			}
+
+			v.addErr(field, err)
+		}
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields of a customer before it is created or
+// updated.
+func (c *Customer) Validate() error {
+	v := &validator{}
+
+	v.email("email", c.Email)
+	v.locale("locale", c.Locale)
+	v.metadata("metadata", c.Metadata)
+
+	return v.err()
+}
+
+// Validate checks the fields of a subscription before it is created or
+// updated.
+func (s *Subscription) Validate() error {
+	v := &validator{}
+
+	v.amount("amount", s.Amount)
+	v.url("webhookUrl", s.WebhookURL)
+	v.metadata("metadata", s.Metadata)
+
+	if err := s.validateInterval(); err != nil {
+		v.addErr("interval", err)
+	}
+
+	if s.Times < 0 {
+		v.add("times", "The number of charges can't be negative.")
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields of a mandate before it is created.
+func (m *Mandate) Validate() error {
+	v := &validator{}
+
+	if m.Method != "" && m.Method != DirectDebit && m.Method != PayPal {
+		v.add("method", "Mandates can only be created for direct debit and PayPal.")
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields of a payment link before it is created.
+func (pl *PaymentLink) Validate() error {
+	v := &validator{}
+
+	if pl.Amount != (Amount{}) {
+		v.amount("amount", &pl.Amount)
+	}
+
+	v.url("redirectUrl", pl.RedirectURL)
+	v.url("webhookUrl", pl.WebhookURL)
+
+	return v.err()
+}
+
+// Validate checks the tracking information and lines of a shipment.
+func (cs *CreateShipmentRequest) Validate() error {
+	v := &validator{}
+
+	for i, l := range cs.Lines {
+		if l.Quantity < 0 {
+			v.add(fmt.Sprintf("lines.%d.quantity", i), "The quantity can't be negative.")
+		}
+	}
+
+	t := cs.Tracking
+	if t != (ShipmentTracking{}) {
+		if t.Carrier == "" {
+			v.add("tracking.carrier", "The carrier is required when tracking is provided.")
+		}
+
+		if t.Code == "" {
+			v.add("tracking.code", "The code is required when tracking is provided.")
+		}
+
+		v.url("tracking.url", t.URL)
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields of a profile before it is created or
+// updated.
+func (p *Profile) Validate() error {
+	v := &validator{}
+
+	v.email("email", p.Email)
+	v.phone("phone", string(p.Phone))
+	v.url("website", p.Website)
+
+	return v.err()
+}
+
+// Validate checks that the onboarding data has at least one field and
+// that the given fields are valid.
+func (od *OnboardingData) Validate() error {
+	v := &validator{}
+
+	org, profile := od.Organization, od.Profile
+	if org.Name == "" && org.Address == nil && org.RegistrationNumber == "" && org.VatNumber == "" &&
+		org.VatRegulation == "" && profile == (OnboardingData{}).Profile {
+		v.add("", "The onboarding data must contain at least one field.")
+	}
+
+	if org.Address != nil {
+		v.address("organization.address", *org.Address)
+	}
+
+	v.url("profile.url", profile.URL)
+	v.email("profile.email", profile.Email)
+	v.phone("profile.phone", profile.Phone)
+
+	return v.err()
+}
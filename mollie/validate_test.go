@@ -0,0 +1,211 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldsOf(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var ve *ValidationError
+	require.True(t, errors.As(err, &ve), "%v", err)
+
+	var fields []string
+	for _, f := range ve.Fields {
+		assert.Equal(t, http.StatusUnprocessableEntity, f.Status)
+		fields = append(fields, f.Field)
+	}
+
+	return fields
+}
+
+func TestPayment_Validate(t *testing.T) {
+	valid := Payment{
+		Amount:       &Amount{Currency: "EUR", Value: "10.00"},
+		Locale:       Dutch,
+		BillingEmail: "piet@mondriaan.com",
+		RedirectURL:  "https://webshop.example.org/order/12345/",
+		BillingAddress: &Address{
+			StreetAndNumber: "Keizersgracht 126",
+			PostalCode:      "1015 CW",
+			City:            "Amsterdam",
+			Country:         "NL",
+		},
+		ShippingAddress: &PaymentDetailsAddress{Phone: "+31208202070"},
+	}
+	assert.Nil(t, valid.Validate())
+
+	invalid := Payment{
+		Amount:          &Amount{Currency: "EUR", Value: "10"},
+		Locale:          "nl",
+		BillingEmail:    "piet",
+		RedirectURL:     "/order/12345",
+		WebhookURL:      "ftp://webshop.example.org",
+		BillingAddress:  &Address{City: "Amsterdam", Region: "Noord-Holland", Country: "Netherlands"},
+		ShippingAddress: &PaymentDetailsAddress{Phone: "020 820 2070", Email: "not an email"},
+		Routing:         []*PaymentRouting{{Amount: &Amount{Currency: "USD", Value: "1.00"}}},
+	}
+
+	assert.Equal(t, []string{
+		"amount.value",
+		"locale",
+		"billingEmail",
+		"redirectUrl",
+		"webhookUrl",
+		"billingAddress.streetAndNumber",
+		"billingAddress.postalCode",
+		"billingAddress.country",
+		"shippingAddress.email",
+		"shippingAddress.phone",
+		"routing",
+	}, fieldsOf(t, invalid.Validate()))
+
+	for value, ok := range map[string]bool{"10.00": true, "-1.50": true, "10.5": false, "1,00": false, "10.001": false} {
+		p := Payment{Amount: &Amount{Currency: "EUR", Value: value}}
+		assert.Equal(t, ok, p.Validate() == nil, value)
+	}
+
+	p := Payment{Amount: &Amount{Currency: "JPY", Value: "1000"}}
+	assert.Nil(t, p.Validate())
+
+	p = Payment{Amount: &Amount{Currency: "euro", Value: "10.00"}}
+	assert.Equal(t, []string{"amount.currency"}, fieldsOf(t, p.Validate()))
+}
+
+func TestOrder_Validate(t *testing.T) {
+	o, err := NewOrderBuilder("EUR").Product("LEGO", 1, "10.00", "21").Build(Order{
+		Locale:         English,
+		BillingAddress: &OrderAddress{GivenName: "Piet", Email: "piet@mondriaan.com", Phone: "+31208202070", City: "Amsterdam"},
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"billingAddress.streetAndNumber",
+		"billingAddress.postalCode",
+		"billingAddress.country",
+	}, fieldsOf(t, o.Validate()))
+
+	o.BillingAddress = nil
+	assert.Nil(t, o.Validate())
+
+	o.Amount = &Amount{Currency: "EUR", Value: "11.00"}
+	assert.Equal(t, []string{"amount"}, fieldsOf(t, o.Validate()))
+
+	o.Lines[0].TotalAmount = &Amount{Currency: "EUR", Value: "11.00"}
+	assert.Equal(t, []string{"lines"}, fieldsOf(t, o.Validate()))
+
+	o.Lines[0].Name = ""
+	o.Lines[0].VatAmount = &Amount{Currency: "EUR", Value: "1.7355"}
+	assert.Equal(t, []string{"lines.0.name", "lines.0.vatAmount.value"}, fieldsOf(t, o.Validate()))
+
+	// updates only send the addresses.
+	assert.Nil(t, (&Order{ShippingAddress: OrderAddress{GivenName: "Piet", FamilyName: "Mondriaan"}}).Validate())
+}
+
+func TestRequests_Validate(t *testing.T) {
+	data := &OnboardingData{}
+	data.Organization.Address = &Address{Region: "Noord-Holland"}
+	data.Profile.Phone = "0208202070"
+
+	cases := []struct {
+		name    string
+		request interface{ Validate() error }
+		fields  []string
+	}{
+		{"valid customer", &Customer{Email: "piet@mondriaan.com", Locale: German}, nil},
+		{"customer", &Customer{Email: "piet@", Locale: "de"}, []string{"email", "locale"}},
+		{"valid subscription", &Subscription{Amount: &Amount{Currency: "EUR", Value: "25.00"}, Interval: "1 month", Times: 4}, nil},
+		{"subscription", &Subscription{Amount: &Amount{Currency: "EUR", Value: "25"}, Interval: "13 months", Times: -1}, []string{"amount.value", "interval", "times"}},
+		{"valid mandate", &Mandate{Method: DirectDebit, ConsumerName: "John Doe"}, nil},
+		{"mandate", &Mandate{Method: IDeal}, []string{"method"}},
+		{"valid payment link", &PaymentLink{Description: "Bicycle tires"}, nil},
+		{"payment link", &PaymentLink{Amount: Amount{Currency: "EUR", Value: "24.9"}, WebhookURL: "webhook"}, []string{"amount.value", "webhookUrl"}},
+		{"valid shipment", &CreateShipmentRequest{Tracking: ShipmentTracking{Carrier: "PostNL", Code: "3SKABA000000000"}}, nil},
+		{"shipment", &CreateShipmentRequest{
+			Lines:    []OrderLine{{Quantity: -1}},
+			Tracking: ShipmentTracking{URL: "postnl.nl"},
+		}, []string{"lines.0.quantity", "tracking.carrier", "tracking.code", "tracking.url"}},
+		{"valid profile", &Profile{Email: "info@mywebsite.com", Phone: "+31208202070", Website: "https://www.mywebsite.com"}, nil},
+		{"profile", &Profile{Email: "info", Phone: "+0031208202070", Website: "www.mywebsite.com"}, []string{"email", "phone", "website"}},
+		{"empty onboarding data", &OnboardingData{}, []string{""}},
+		{"onboarding data", data, []string{
+			"organization.address.streetAndNumber",
+			"organization.address.postalCode",
+			"organization.address.city",
+			"organization.address.country",
+			"profile.phone",
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.fields, fieldsOf(t, c.request.Validate()))
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := (&Customer{Email: "piet@", Locale: "de"}).Validate()
+
+	assert.EqualError(t, err, "validation_error: "+
+		"422 Unprocessable Entity: The email address is not valid., affected field: email; "+
+		`422 Unprocessable Entity: The locale "de" is not supported., affected field: locale`)
+
+	var be *BaseError
+	require.True(t, errors.As(err, &be))
+	assert.Equal(t, "email", be.Field)
+}
+
+func TestValidationError_LocalChecks(t *testing.T) {
+	err := (&Payment{
+		Amount:  &Amount{Currency: "EUR", Value: "10.00"},
+		Routing: []*PaymentRouting{{Amount: &Amount{Currency: "EUR", Value: "12.00"}}},
+	}).Validate()
+
+	var ve *ValidationError
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, "the sum of the routes exceeds the payment amount", ve.Fields[0].Detail)
+	assert.True(t, errors.Is(err, errRoutingExceedsPayment))
+
+	err = (&Subscription{Amount: &Amount{Currency: "EUR", Value: "25.00"}, Interval: "13 months"}).Validate()
+
+	require.True(t, errors.As(err, &ve))
+	assert.NotContains(t, ve.Fields[0].Detail, "interval_error")
+	assert.True(t, errors.Is(err, errIntervalTooLong))
+}
+
+func TestClient_Validation(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	setup()
+	defer teardown()
+
+	requests := 0
+
+	tMux.HandleFunc("/v2/customers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"resource": "customer", "id": "cst_8wmqcHMN4U"}`))
+	})
+
+	_, _, err := tClient.Customers.Create(context.Background(), Customer{Email: "john"})
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, requests)
+
+	tClient.config.WithValidation(false)
+
+	_, c, err := tClient.Customers.Create(context.Background(), Customer{Email: "john"})
+	require.Nil(t, err)
+	assert.Equal(t, "cst_8wmqcHMN4U", c.ID)
+	assert.Equal(t, 1, requests)
+}
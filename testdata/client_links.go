@@ -0,0 +1,36 @@
+package testdata
+
+// CreateClientLinkRequest example.
+const CreateClientLinkRequest = `{
+    "owner": {
+        "email": "norris@chucknorrisfacts.net",
+        "givenName": "Chuck",
+        "familyName": "Norris",
+        "locale": "en_US"
+    },
+    "name": "Mollie B.V.",
+    "address": {
+        "streetAndNumber": "Keizersgracht 126",
+        "postalCode": "1015 CW",
+        "city": "Amsterdam",
+        "country": "NL"
+    },
+    "registrationNumber": "30204462",
+    "vatNumber": "NL815839091B01"
+}`
+
+// CreateClientLinkResponse example.
+const CreateClientLinkResponse = `{
+    "id": "csr_vZCnNQsV2UtfXxYifWKWH",
+    "resource": "client-link",
+    "_links": {
+        "clientLink": {
+            "href": "https://my.mollie.com/dashboard/client-link/finalize/csr_vZCnNQsV2UtfXxYifWKWH",
+            "type": "text/html"
+        },
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/clients-api/create-client-link",
+            "type": "text/html"
+        }
+    }
+}`
@@ -82,6 +82,46 @@ const UpdateCustomerResponse = `{
     }
 }`
 
+// ListCustomersLastPageResponse example
+const ListCustomersLastPageResponse = `{
+    "count": 1,
+    "_embedded": {
+        "customers": [
+            {
+                "resource": "customer",
+                "id": "cst_stTC2WHAuS",
+                "mode": "test",
+                "name": "Customer B",
+                "email": "customer-b@example.org",
+                "locale": "en_US",
+                "metadata": null,
+                "createdAt": "2018-04-07T10:12:42.0Z",
+                "_links": {
+                    "self": {
+                        "href": "https://api.mollie.com/v2/customers/cst_stTC2WHAuS",
+                        "type": "application/hal+json"
+                    }
+                }
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/customers?from=cst_stTC2WHAuS",
+            "type": "application/hal+json"
+        },
+        "previous": {
+            "href": "https://api.mollie.com/v2/customers",
+            "type": "application/hal+json"
+        },
+        "next": null,
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/customers-api/list-customers",
+            "type": "text/html"
+        }
+    }
+}`
+
 // ListCustomersResponse example
 const ListCustomersResponse = `{
     "count": 3,
@@ -143,3 +143,56 @@ const ListMandatesResponse = `
         }
     }
 }`
+
+// ListMandatesLastPageResponse example of the last page of a mandates list.
+const ListMandatesLastPageResponse = `{
+    "count": 2,
+    "_embedded": {
+        "mandates": [
+            {
+                "resource": "mandate",
+                "id": "mdt_pWUnw6pkBN",
+                "mode": "test",
+                "status": "pending",
+                "method": "directdebit",
+                "details": {
+                    "consumerName": "John Doe",
+                    "consumerAccount": "NL55INGB0000000000",
+                    "consumerBic": "INGBNL2A"
+                },
+                "mandateReference": null,
+                "signatureDate": "2018-05-07",
+                "createdAt": "2018-05-07T10:49:08+00:00"
+            },
+            {
+                "resource": "mandate",
+                "id": "mdt_h3gAaD5zP",
+                "mode": "test",
+                "status": "invalid",
+                "method": "creditcard",
+                "details": {
+                    "cardHolder": "John Doe",
+                    "cardNumber": "1234",
+                    "cardLabel": "Mastercard",
+                    "cardFingerprint": "fHB3CCKx9REkz8fPplT8N4nq",
+                    "cardExpiryDate": "2016-03-31"
+                },
+                "mandateReference": null,
+                "signatureDate": "2016-03-01",
+                "createdAt": "2016-03-01T10:49:08+00:00"
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/customers/cst_8wmqcHMN4U/mandates?from=mdt_AcQl5fdL4h&limit=5",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null,
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/mandates-api/list-mandates",
+            "type": "text/html"
+        }
+    }
+}`
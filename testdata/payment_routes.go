@@ -0,0 +1,77 @@
+package testdata
+
+// ListPaymentRoutesResponse example.
+const ListPaymentRoutesResponse = `{
+    "count": 2,
+    "_embedded": {
+        "routes": [
+            {
+                "resource": "route",
+                "id": "rt_k6cjd01h",
+                "paymentId": "tr_7UhSN1zuXS",
+                "amount": {
+                    "value": "7.50",
+                    "currency": "EUR"
+                },
+                "destination": {
+                    "type": "organization",
+                    "organizationId": "org_23456"
+                },
+                "releaseDate": "2022-11-28",
+                "createdAt": "2022-11-18T12:00:00+00:00"
+            },
+            {
+                "resource": "route",
+                "id": "rt_9dk4al1n",
+                "paymentId": "tr_7UhSN1zuXS",
+                "amount": {
+                    "value": "2.50",
+                    "currency": "EUR"
+                },
+                "destination": {
+                    "type": "organization",
+                    "organizationId": "org_56789"
+                },
+                "releaseDate": "2022-12-01",
+                "createdAt": "2022-11-18T12:00:00+00:00"
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/payments/tr_7UhSN1zuXS/routes",
+            "type": "application/hal+json"
+        },
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/payments-api/list-payment-routes",
+            "type": "text/html"
+        }
+    }
+}`
+
+// UpdatePaymentRouteResponse example.
+const UpdatePaymentRouteResponse = `{
+    "resource": "route",
+    "id": "rt_k6cjd01h",
+    "paymentId": "tr_7UhSN1zuXS",
+    "amount": {
+        "value": "7.50",
+        "currency": "EUR"
+    },
+    "destination": {
+        "type": "organization",
+        "organizationId": "org_23456"
+    },
+    "releaseDate": "2022-12-24",
+    "createdAt": "2022-11-18T12:00:00+00:00",
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/payments/tr_7UhSN1zuXS/routes/rt_k6cjd01h",
+            "type": "application/hal+json"
+        },
+        "payment": {
+            "href": "https://api.mollie.com/v2/payments/tr_7UhSN1zuXS",
+            "type": "application/hal+json"
+        }
+    }
+}`
@@ -85,3 +85,100 @@ const GetRefundListResponse = `{
         }
     }
 }`
+
+// ListRefundsEmbeddedPaymentResponse example of a refunds list with embedded payments.
+const ListRefundsEmbeddedPaymentResponse = `{
+    "count": 2,
+    "_embedded": {
+        "refunds": [
+            {
+                "resource": "refund",
+                "id": "re_4qqhO89gsT",
+                "amount": {
+                    "currency": "EUR",
+                    "value": "5.95"
+                },
+                "status": "queued",
+                "createdAt": "2018-03-14T17:09:02.0Z",
+                "description": "Order",
+                "paymentId": "tr_WDqYK6vllg",
+                "_embedded": {
+                    "payment": {
+                        "resource": "payment",
+                        "id": "tr_WDqYK6vllg",
+                        "mode": "test",
+                        "createdAt": "2018-03-14T16:59:02.0Z",
+                        "amount": {
+                            "value": "35.07",
+                            "currency": "EUR"
+                        },
+                        "description": "Order 33",
+                        "method": "ideal",
+                        "status": "paid",
+                        "profileId": "pfl_QkEhN94Ba"
+                    }
+                },
+                "_links": {
+                    "self": {
+                        "href": "https://api.mollie.com/v2/payments/tr_WDqYK6vllg/refunds/re_4qqhO89gsT",
+                        "type": "application/hal+json"
+                    },
+                    "payment": {
+                        "href": "https://api.mollie.com/v2/payments/tr_WDqYK6vllg",
+                        "type": "application/hal+json"
+                    }
+                }
+            },
+            {
+                "resource": "refund",
+                "id": "re_APBiGPH2vV",
+                "amount": {
+                    "currency": "EUR",
+                    "value": "10.00"
+                },
+                "status": "refunded",
+                "createdAt": "2018-03-13T17:09:02.0Z",
+                "description": "Order",
+                "paymentId": "tr_7UhSN1zuXS",
+                "_embedded": {
+                    "payment": {
+                        "resource": "payment",
+                        "id": "tr_7UhSN1zuXS",
+                        "mode": "test",
+                        "createdAt": "2018-03-13T16:59:02.0Z",
+                        "amount": {
+                            "value": "10.00",
+                            "currency": "EUR"
+                        },
+                        "description": "Order 32",
+                        "method": "ideal",
+                        "status": "paid",
+                        "profileId": "pfl_QkEhN94Ba"
+                    }
+                },
+                "_links": {
+                    "self": {
+                        "href": "https://api.mollie.com/v2/payments/tr_7UhSN1zuXS/refunds/re_APBiGPH2vV",
+                        "type": "application/hal+json"
+                    },
+                    "payment": {
+                        "href": "https://api.mollie.com/v2/payments/tr_7UhSN1zuXS",
+                        "type": "application/hal+json"
+                    }
+                }
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/refunds?embed=payment&limit=5",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null,
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/refunds-api/list-refunds",
+            "type": "text/html"
+        }
+    }
+}`
@@ -420,3 +420,241 @@ const ListSettlementsResponse = `
         }
     }
 }`
+
+// ListSettlementPaymentsResponse example
+const ListSettlementPaymentsResponse = `{
+    "count": 4,
+    "_embedded": {
+        "payments": [
+            {
+                "resource": "payment",
+                "id": "tr_7UhSN1zuXS",
+                "mode": "live",
+                "createdAt": "2018-04-01T10:00:00+00:00",
+                "amount": {
+                    "value": "10.00",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "10.00",
+                    "currency": "EUR"
+                },
+                "description": "Order tr_7UhSN1zuXS",
+                "method": "ideal",
+                "status": "paid",
+                "paidAt": "2018-04-01T10:01:00+00:00",
+                "settlementId": "stl_jDk30akdN",
+                "sequenceType": "oneoff"
+            },
+            {
+                "resource": "payment",
+                "id": "tr_8WhJKGmgBy",
+                "mode": "live",
+                "createdAt": "2018-04-02T10:00:00+00:00",
+                "amount": {
+                    "value": "15.00",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "15.00",
+                    "currency": "EUR"
+                },
+                "description": "Order tr_8WhJKGmgBy",
+                "method": "ideal",
+                "status": "paid",
+                "paidAt": "2018-04-02T10:01:00+00:00",
+                "settlementId": "stl_jDk30akdN",
+                "sequenceType": "oneoff"
+            },
+            {
+                "resource": "payment",
+                "id": "tr_9VhKLHnhCz",
+                "mode": "live",
+                "createdAt": "2018-04-02T10:00:00+00:00",
+                "amount": {
+                    "value": "20.00",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "20.00",
+                    "currency": "EUR"
+                },
+                "description": "Order tr_9VhKLHnhCz",
+                "method": "ideal",
+                "status": "paid",
+                "paidAt": "2018-04-02T10:01:00+00:00",
+                "settlementId": "stl_jDk30akdN",
+                "sequenceType": "oneoff"
+            },
+            {
+                "resource": "payment",
+                "id": "tr_AWiLMIoiDa",
+                "mode": "live",
+                "createdAt": "2018-04-03T10:00:00+00:00",
+                "amount": {
+                    "value": "11.10",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "11.10",
+                    "currency": "EUR"
+                },
+                "description": "Order tr_AWiLMIoiDa",
+                "method": "ideal",
+                "status": "paid",
+                "paidAt": "2018-04-03T10:01:00+00:00",
+                "settlementId": "stl_jDk30akdN",
+                "sequenceType": "oneoff"
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/settlements/stl_jDk30akdN/payments",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": {
+            "href": "https://api.mollie.com/v2/settlements/stl_jDk30akdN/payments?from=tr_BXjMNJpjEb&limit=4",
+            "type": "application/hal+json"
+        }
+    }
+}`
+
+// ListSettlementPaymentsLastPageResponse example
+const ListSettlementPaymentsLastPageResponse = `{
+    "count": 2,
+    "_embedded": {
+        "payments": [
+            {
+                "resource": "payment",
+                "id": "tr_BXjMNJpjEb",
+                "mode": "live",
+                "createdAt": "2018-04-04T10:00:00+00:00",
+                "amount": {
+                    "value": "20.00",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "20.00",
+                    "currency": "EUR"
+                },
+                "description": "Order tr_BXjMNJpjEb",
+                "method": "ideal",
+                "status": "paid",
+                "paidAt": "2018-04-04T10:01:00+00:00",
+                "settlementId": "stl_jDk30akdN",
+                "sequenceType": "oneoff"
+            },
+            {
+                "resource": "payment",
+                "id": "tr_CYkNOKqkFc",
+                "mode": "live",
+                "createdAt": "2018-04-05T10:00:00+00:00",
+                "amount": {
+                    "value": "10.00",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "10.00",
+                    "currency": "EUR"
+                },
+                "description": "Order tr_CYkNOKqkFc",
+                "method": "ideal",
+                "status": "paid",
+                "paidAt": "2018-04-05T10:01:00+00:00",
+                "settlementId": "stl_jDk30akdN",
+                "sequenceType": "oneoff"
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/settlements/stl_jDk30akdN/payments",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null
+    }
+}`
+
+// ListSettlementRefundsResponse example
+const ListSettlementRefundsResponse = `{
+    "count": 2,
+    "_embedded": {
+        "refunds": [
+            {
+                "resource": "refund",
+                "id": "re_4qqhO89gsT",
+                "amount": {
+                    "value": "20.00",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "-20.00",
+                    "currency": "EUR"
+                },
+                "settlementId": "stl_jDk30akdN",
+                "status": "refunded",
+                "paymentId": "tr_7UhSN1zuXS",
+                "createdAt": "2018-04-03T12:00:00+00:00"
+            },
+            {
+                "resource": "refund",
+                "id": "re_5rriP90htU",
+                "amount": {
+                    "value": "23.20",
+                    "currency": "EUR"
+                },
+                "settlementAmount": {
+                    "value": "-23.20",
+                    "currency": "EUR"
+                },
+                "settlementId": "stl_jDk30akdN",
+                "status": "refunded",
+                "paymentId": "tr_CYkNOKqkFc",
+                "createdAt": "2018-04-05T12:00:00+00:00"
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/settlements/stl_jDk30akdN/refunds",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null
+    }
+}`
+
+// ListSettlementChargebacksResponse example
+const ListSettlementChargebacksResponse = `{
+    "count": 0,
+    "_embedded": {
+        "chargebacks": []
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/settlements/stl_jDk30akdN/chargebacks",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null
+    }
+}`
+
+// ListSettlementCapturesResponse example
+const ListSettlementCapturesResponse = `{
+    "count": 0,
+    "_embedded": {
+        "captures": []
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/settlements/stl_jDk30akdN/captures",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null
+    }
+}`
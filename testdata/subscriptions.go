@@ -138,3 +138,61 @@ const ListAllSubscriptionsResponse = `{
         }
     }
 }`
+
+// ListCustomerSubscriptionsResponse example.
+const ListCustomerSubscriptionsResponse = `{
+    "count": 2,
+    "_embedded": {
+        "subscriptions": [
+            {
+                "resource": "subscription",
+                "id": "sub_rVKGtNd6s3",
+                "mode": "live",
+                "createdAt": "2018-06-01T12:23:34+00:00",
+                "status": "active",
+                "amount": {
+                    "value": "25.00",
+                    "currency": "EUR"
+                },
+                "times": 4,
+                "timesRemaining": 3,
+                "interval": "3 months",
+                "startDate": "2016-06-01",
+                "nextPaymentDate": "2016-09-01",
+                "description": "Quarterly payment",
+                "method": null,
+                "mandateId": "mdt_AcQl5fdL4h",
+                "webhookUrl": "https://webshop.example.org/subscriptions/webhook"
+            },
+            {
+                "resource": "subscription",
+                "id": "sub_mnfbwhMfvo",
+                "mode": "live",
+                "createdAt": "2018-04-01T12:23:34+00:00",
+                "status": "canceled",
+                "amount": {
+                    "value": "10.00",
+                    "currency": "EUR"
+                },
+                "interval": "1 month",
+                "startDate": "2018-04-01",
+                "description": "Monthly payment",
+                "method": null,
+                "canceledAt": "2018-05-01T12:23:34+00:00",
+                "webhookUrl": "https://webshop.example.org/subscriptions/webhook"
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/customers/cst_8wmqcHMN4U/subscriptions",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": null,
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/subscriptions-api/list-subscriptions",
+            "type": "text/html"
+        }
+    }
+}`
@@ -0,0 +1,121 @@
+package testdata
+
+// GetTerminalResponse example.
+const GetTerminalResponse = `{
+    "id": "term_7MgL4wea46qkRcoTZjWEH",
+    "profileId": "pfl_QkEhN94Ba",
+    "status": "active",
+    "brand": "PAX",
+    "model": "A920",
+    "serialNumber": "1234567890",
+    "currency": "EUR",
+    "description": "Terminal #12345",
+    "createdAt": "2022-02-12T11:58:35.0Z",
+    "updatedAt": "2022-11-15T13:32:11.0Z",
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/terminals/term_7MgL4wea46qkRcoTZjWEH",
+            "type": "application/hal+json"
+        },
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/terminals-api/get-terminal",
+            "type": "text/html"
+        }
+    }
+}`
+
+// ListTerminalsResponse example.
+const ListTerminalsResponse = `{
+    "count": 2,
+    "_embedded": {
+        "terminals": [
+            {
+                "id": "term_7MgL4wea46qkRcoTZjWEH",
+                "profileId": "pfl_QkEhN94Ba",
+                "status": "active",
+                "brand": "PAX",
+                "model": "A920",
+                "serialNumber": "1234567890",
+                "currency": "EUR",
+                "description": "Terminal #12345",
+                "createdAt": "2022-02-12T11:58:35.0Z",
+                "updatedAt": "2022-11-15T13:32:11.0Z",
+                "_links": {
+                    "self": {
+                        "href": "https://api.mollie.com/v2/terminals/term_7MgL4wea46qkRcoTZjWEH",
+                        "type": "application/hal+json"
+                    }
+                }
+            },
+            {
+                "id": "term_8HgL4wea46qkRcoTZjWEH",
+                "profileId": "pfl_QkEhN94Ba",
+                "status": "pending",
+                "brand": "PAX",
+                "model": "A920",
+                "serialNumber": "1234567891",
+                "currency": "EUR",
+                "description": "Terminal #12346",
+                "createdAt": "2022-02-12T11:58:35.0Z",
+                "updatedAt": "2022-11-15T13:32:11.0Z",
+                "_links": {
+                    "self": {
+                        "href": "https://api.mollie.com/v2/terminals/term_8HgL4wea46qkRcoTZjWEH",
+                        "type": "application/hal+json"
+                    }
+                }
+            }
+        ]
+    },
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/terminals?limit=5",
+            "type": "application/hal+json"
+        },
+        "previous": null,
+        "next": {
+            "href": "https://api.mollie.com/v2/terminals?from=term_7MgL4wea46qkRcoTZjWEI&limit=5",
+            "type": "application/hal+json"
+        },
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/terminals-api/list-terminals",
+            "type": "text/html"
+        }
+    }
+}`
+
+// CreatePointOfSalePaymentResponse example.
+const CreatePointOfSalePaymentResponse = `{
+    "resource": "payment",
+    "id": "tr_7UhSN1zuXS",
+    "mode": "live",
+    "createdAt": "2022-05-04T12:00:00+00:00",
+    "amount": {
+        "value": "10.00",
+        "currency": "EUR"
+    },
+    "description": "Order #12345",
+    "method": "pointofsale",
+    "metadata": null,
+    "status": "open",
+    "isCancelable": false,
+    "expiresAt": "2022-05-04T12:15:00+00:00",
+    "profileId": "pfl_QkEhN94Ba",
+    "sequenceType": "oneoff",
+    "terminalId": "term_7MgL4wea46qkRcoTZjWEH",
+    "webhookUrl": "https://webshop.example.org/payments/webhook/",
+    "_links": {
+        "self": {
+            "href": "https://api.mollie.com/v2/payments/tr_7UhSN1zuXS",
+            "type": "application/json"
+        },
+        "terminal": {
+            "href": "https://api.mollie.com/v2/terminals/term_7MgL4wea46qkRcoTZjWEH",
+            "type": "application/hal+json"
+        },
+        "documentation": {
+            "href": "https://docs.mollie.com/reference/v2/payments-api/create-payment",
+            "type": "text/html"
+        }
+    }
+}`